@@ -0,0 +1,140 @@
+package amazon
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tipPattern matches "Tip", "Driver tip" and "Delivery tip" as a word, not inside one
+var tipPattern = regexp.MustCompile(`\btips?\b`)
+
+// AdjustmentType classifies a row of an order's charge summary
+type AdjustmentType string
+
+// Charge summary row types
+const (
+	AdjustmentSubtotal       AdjustmentType = "subtotal"           // Item(s) Subtotal
+	AdjustmentShipping       AdjustmentType = "shipping"           // Shipping & Handling
+	AdjustmentPromotion      AdjustmentType = "promotion"          // Promotion Applied, Free Shipping, other discounts
+	AdjustmentSubscribeSave  AdjustmentType = "subscribe_and_save" // Subscribe & Save discount
+	AdjustmentCoupon         AdjustmentType = "coupon"             // Coupon Savings
+	AdjustmentTotalBeforeTax AdjustmentType = "total_before_tax"   // Total before tax (informational)
+	AdjustmentTax            AdjustmentType = "tax"                // Estimated tax
+	AdjustmentTip            AdjustmentType = "tip"                // Driver tip on grocery orders
+	AdjustmentBagFee         AdjustmentType = "bag_fee"            // Bag fee on grocery orders
+	AdjustmentGiftCard       AdjustmentType = "gift_card"          // Gift Card Amount
+	AdjustmentRewardPoints   AdjustmentType = "reward_points"      // Rewards Points
+	AdjustmentGrandTotal     AdjustmentType = "grand_total"        // Grand Total (informational)
+	AdjustmentRefund         AdjustmentType = "refund"             // Refund Total, shown after the grand total (informational)
+	AdjustmentOther          AdjustmentType = "other"              // Unrecognized rows, e.g. import fees
+)
+
+// Adjustment is a single row of an order's charge summary
+type Adjustment struct {
+	Type   AdjustmentType `json:"type"`
+	Label  string         `json:"label"`  // Label as shown, e.g. "Promotion Applied:"
+	Amount Money          `json:"amount"` // Signed: discounts and credits are negative
+}
+
+// IsDiscount reports whether the adjustment is a price reduction (promotion,
+// Subscribe & Save or coupon) rather than a charge or a payment credit
+func (t AdjustmentType) IsDiscount() bool {
+	switch t {
+	case AdjustmentPromotion, AdjustmentSubscribeSave, AdjustmentCoupon:
+		return true
+	}
+	return false
+}
+
+// isCredit reports whether the adjustment always reduces the amount owed
+func (t AdjustmentType) isCredit() bool {
+	return t.IsDiscount() || t == AdjustmentGiftCard || t == AdjustmentRewardPoints
+}
+
+// isSummary reports whether the adjustment restates a running total, or
+// reports money returned later, rather than adding to the amount charged
+func (t AdjustmentType) isSummary() bool {
+	return t == AdjustmentTotalBeforeTax || t == AdjustmentGrandTotal || t == AdjustmentRefund
+}
+
+// classifyAdjustment determines a charge summary row's type from its label.
+// Order matters: "Free Shipping" is a discount, "Total before tax" is not a tax.
+func classifyAdjustment(label string) AdjustmentType {
+	label = strings.ToLower(label)
+
+	switch {
+	case strings.Contains(label, "refund"):
+		return AdjustmentRefund
+	case strings.Contains(label, "grand total"):
+		return AdjustmentGrandTotal
+	case strings.Contains(label, "total before tax"):
+		return AdjustmentTotalBeforeTax
+	case strings.Contains(label, "item") && strings.Contains(label, "subtotal"):
+		return AdjustmentSubtotal
+	case strings.Contains(label, "gift card"):
+		return AdjustmentGiftCard
+	case strings.Contains(label, "reward") || strings.Contains(label, "points"):
+		return AdjustmentRewardPoints
+	case strings.Contains(label, "subscribe"):
+		return AdjustmentSubscribeSave
+	case strings.Contains(label, "coupon"):
+		return AdjustmentCoupon
+	case strings.Contains(label, "promotion") || strings.Contains(label, "discount") ||
+		strings.Contains(label, "savings") || strings.Contains(label, "free shipping"):
+		return AdjustmentPromotion
+	case tipPattern.MatchString(label):
+		return AdjustmentTip
+	case strings.Contains(label, "bag"):
+		return AdjustmentBagFee
+	case strings.Contains(label, "shipping") || strings.Contains(label, "handling") || strings.Contains(label, "delivery fee"):
+		return AdjustmentShipping
+	case strings.Contains(label, "tax"):
+		return AdjustmentTax
+	}
+	return AdjustmentOther
+}
+
+// Discounts returns the sum of the order's promotions, Subscribe & Save
+// discounts and coupons, as a negative amount
+func (o *Order) Discounts() Money {
+	var total Money
+	for _, adj := range o.Adjustments {
+		if adj.Type.IsDiscount() {
+			total = total.Add(adj.Amount)
+		}
+	}
+	return total
+}
+
+// VerifyTotals checks that the order's charge summary adds up: subtotal +
+// shipping + tax + other charges - discounts - gift card and points credits
+// must equal the grand total. It returns a *TotalMismatchError if not, an
+// error wrapping ErrCurrencyMismatch if the rows are in different currencies,
+// and nil if the order has no charge summary to check.
+func (o *Order) VerifyTotals() error {
+	if len(o.Adjustments) == 0 || o.Total.IsZero() {
+		return nil
+	}
+
+	var computed Money
+	for _, adj := range o.Adjustments {
+		if adj.Type.isSummary() {
+			continue
+		}
+		sum, err := computed.CheckedAdd(adj.Amount)
+		if err != nil {
+			return fmt.Errorf("order %s: %w", o.ID, err)
+		}
+		computed = sum
+	}
+
+	diff, err := o.Total.CheckedSub(computed)
+	if err != nil {
+		return fmt.Errorf("order %s: %w", o.ID, err)
+	}
+	if !diff.IsZero() {
+		return &TotalMismatchError{OrderID: o.ID, Computed: computed, Total: o.Total}
+	}
+	return nil
+}
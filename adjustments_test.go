@@ -0,0 +1,132 @@
+package amazon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testChargeSummaryPage = `<html><body>
+<span>Order # 114-9733092-9360267</span>
+<div id="od-subtotals">
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Item(s) Subtotal:</span><span class="od-line-item-row-content">$61.97</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Shipping &amp; Handling:</span><span class="od-line-item-row-content">$5.99</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Free Shipping:</span><span class="od-line-item-row-content">-$5.99</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Promotion Applied:</span><span class="od-line-item-row-content">-$5.00</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Subscribe &amp; Save discount:</span><span class="od-line-item-row-content">-$2.10</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Coupon Savings:</span><span class="od-line-item-row-content">$1.50</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Total before tax:</span><span class="od-line-item-row-content">$53.37</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Estimated tax to be collected:</span><span class="od-line-item-row-content">$3.74</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Gift Card Amount:</span><span class="od-line-item-row-content">-$10.00</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Rewards Points:</span><span class="od-line-item-row-content">-$1.11</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Grand Total:</span><span class="od-line-item-row-content">$46.00</span></div>
+</div>
+</body></html>`
+
+func TestParseOrderDetails_Adjustments(t *testing.T) {
+	order, err := NewParser().ParseOrderDetails(strings.NewReader(testChargeSummaryPage))
+	if err != nil {
+		t.Fatalf("ParseOrderDetails failed: %v", err)
+	}
+
+	expected := []struct {
+		adjType AdjustmentType
+		amount  string
+	}{
+		{AdjustmentSubtotal, "61.97"},
+		{AdjustmentShipping, "5.99"},
+		{AdjustmentPromotion, "-5.99"},
+		{AdjustmentPromotion, "-5.00"},
+		{AdjustmentSubscribeSave, "-2.10"},
+		{AdjustmentCoupon, "-1.50"},
+		{AdjustmentTotalBeforeTax, "53.37"},
+		{AdjustmentTax, "3.74"},
+		{AdjustmentGiftCard, "-10.00"},
+		{AdjustmentRewardPoints, "-1.11"},
+		{AdjustmentGrandTotal, "46.00"},
+	}
+	if len(order.Adjustments) != len(expected) {
+		t.Fatalf("Expected %d adjustments, got %d", len(expected), len(order.Adjustments))
+	}
+	for i, exp := range expected {
+		adj := order.Adjustments[i]
+		if adj.Type != exp.adjType || adj.Amount.Decimal() != exp.amount {
+			t.Errorf("Adjustment %d (%s): got %s %s, want %s %s", i, adj.Label, adj.Type, adj.Amount.Decimal(), exp.adjType, exp.amount)
+		}
+	}
+
+	// Free Shipping and Total before tax no longer overwrite shipping and tax
+	if order.ShippingFees.Decimal() != "5.99" || order.Tax.Decimal() != "3.74" {
+		t.Errorf("Unexpected shipping %s / tax %s", order.ShippingFees, order.Tax)
+	}
+	if order.Total.Decimal() != "46.00" {
+		t.Errorf("Expected grand total 46.00, got %s", order.Total)
+	}
+	if order.Discounts().Decimal() != "-14.59" {
+		t.Errorf("Expected discounts -14.59, got %s", order.Discounts())
+	}
+
+	if err := order.VerifyTotals(); err != nil {
+		t.Errorf("Expected totals to reconcile, got %v", err)
+	}
+}
+
+func TestVerifyTotals_Mismatch(t *testing.T) {
+	order := &Order{
+		ID:    "114-9733092-9360267",
+		Total: NewMoney(4000, "USD"),
+		Adjustments: []*Adjustment{
+			{Type: AdjustmentSubtotal, Amount: NewMoney(4200, "USD")},
+			{Type: AdjustmentTax, Amount: NewMoney(300, "USD")},
+			{Type: AdjustmentGrandTotal, Amount: NewMoney(4000, "USD")},
+		},
+	}
+
+	err := order.VerifyTotals()
+	if !errors.Is(err, ErrTotalMismatch) {
+		t.Fatalf("Expected ErrTotalMismatch, got %v", err)
+	}
+
+	var mismatch *TotalMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Computed != NewMoney(4500, "USD") {
+		t.Errorf("Expected computed total 45.00 USD, got %+v", mismatch)
+	}
+
+	// Rows in another currency are reported instead of summed
+	order.Adjustments[1].Amount = NewMoney(300, "GBP")
+	if err := order.VerifyTotals(); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	// Orders without a charge summary have nothing to check
+	if err := (&Order{Total: NewMoney(4000, "USD")}).VerifyTotals(); err != nil {
+		t.Errorf("Expected nil for order without adjustments, got %v", err)
+	}
+}
+
+func TestClassifyAdjustment(t *testing.T) {
+	tests := []struct {
+		label    string
+		expected AdjustmentType
+	}{
+		{"Item(s) Subtotal:", AdjustmentSubtotal},
+		{"Shipping & Handling:", AdjustmentShipping},
+		{"Free Shipping:", AdjustmentPromotion},
+		{"Your Coupon Savings", AdjustmentCoupon},
+		{"Total before tax:", AdjustmentTotalBeforeTax},
+		{"Estimated tax to be collected:", AdjustmentTax},
+		{"Gift Card Amount:", AdjustmentGiftCard},
+		{"Rewards Points:", AdjustmentRewardPoints},
+		{"Refund Total", AdjustmentRefund},
+		{"Driver tip:", AdjustmentTip},
+		{"Bag fee:", AdjustmentBagFee},
+		{"Delivery fee:", AdjustmentShipping},
+		{"Import Fees Deposit", AdjustmentOther},
+	}
+
+	for _, tc := range tests {
+		if result := classifyAdjustment(tc.label); result != tc.expected {
+			t.Errorf("classifyAdjustment(%q) = %s, want %s", tc.label, result, tc.expected)
+		}
+	}
+}
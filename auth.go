@@ -33,6 +33,7 @@ type CookieStore struct {
 	cookies  map[string]*Cookie
 	filePath string
 	mu       sync.RWMutex
+	saveMu   sync.Mutex // Serializes writes to filePath
 }
 
 // CookieFile represents the JSON structure for cookie storage
@@ -99,15 +100,20 @@ func (s *CookieStore) Load() error {
 	return nil
 }
 
-// Save writes cookies to the file
+// Save writes cookies to the file. It is safe to call from several
+// goroutines, as happens when requests run concurrently with auto-save.
 func (s *CookieStore) Save() error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	// os.WriteFile truncates before writing, so two overlapping saves can
+	// leave the file holding parts of both and fail to load
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
 
+	s.mu.RLock()
 	cookies := make([]*Cookie, 0, len(s.cookies))
 	for _, c := range s.cookies {
 		cookies = append(cookies, c)
 	}
+	s.mu.RUnlock()
 
 	cookieFile := CookieFile{
 		Cookies:   cookies,
@@ -207,13 +213,19 @@ func ExtractFromCurl(curlCmd string) ([]*Cookie, error) {
 	// Match -b or --cookie followed by the cookie string
 	// Handles: -b 'cookies', -b "cookies", and -b cookies (unquoted until next flag or newline)
 
+	// Scope cookies to the marketplace the command was copied from
+	domain := MarketplaceUS.CookieDomain
+	if m := marketplaceForCurl(curlCmd); m != nil {
+		domain = m.CookieDomain
+	}
+
 	// Try single-quoted first (most common from "Copy as cURL")
 	// This allows double quotes inside the value (e.g., x-main="...")
 	singleQuoteRegex := regexp.MustCompile(`(?:-b|--cookie)\s+'([^']+)'`)
 	matches := singleQuoteRegex.FindStringSubmatch(curlCmd)
 
 	if len(matches) >= 2 {
-		return parseCookieString(matches[1]), nil
+		return parseCookieString(matches[1], domain), nil
 	}
 
 	// Try double-quoted (allows single quotes inside)
@@ -221,7 +233,7 @@ func ExtractFromCurl(curlCmd string) ([]*Cookie, error) {
 	matches = doubleQuoteRegex.FindStringSubmatch(curlCmd)
 
 	if len(matches) >= 2 {
-		return parseCookieString(matches[1]), nil
+		return parseCookieString(matches[1], domain), nil
 	}
 
 	// Try unquoted (stops at newline, backslash, or next -H flag)
@@ -229,14 +241,24 @@ func ExtractFromCurl(curlCmd string) ([]*Cookie, error) {
 	matches = unquotedRegex.FindStringSubmatch(curlCmd)
 
 	if len(matches) >= 2 {
-		return parseCookieString(matches[1]), nil
+		return parseCookieString(matches[1], domain), nil
 	}
 
 	return nil, fmt.Errorf("no cookies found in curl command")
 }
 
-// parseCookieString parses a cookie header string into Cookie objects
-func parseCookieString(cookieStr string) []*Cookie {
+// marketplaceForCurl returns the marketplace of the URL in a curl command, or nil
+func marketplaceForCurl(curlCmd string) *Marketplace {
+	re := regexp.MustCompile(`https?://([^/\s'"]+)`)
+	matches := re.FindStringSubmatch(curlCmd)
+	if len(matches) < 2 {
+		return nil
+	}
+	return marketplaceForHost(matches[1])
+}
+
+// parseCookieString parses a cookie header string into Cookie objects scoped to domain
+func parseCookieString(cookieStr, domain string) []*Cookie {
 	var cookies []*Cookie
 
 	pairs := strings.Split(cookieStr, ";")
@@ -257,7 +279,7 @@ func parseCookieString(cookieStr string) []*Cookie {
 		cookies = append(cookies, &Cookie{
 			Name:   name,
 			Value:  value,
-			Domain: ".amazon.com",
+			Domain: domain,
 			Path:   "/",
 		})
 	}
@@ -265,26 +287,20 @@ func parseCookieString(cookieStr string) []*Cookie {
 	return cookies
 }
 
-// EssentialCookies returns the list of essential cookie names for Amazon
+// EssentialCookies returns the list of essential cookie names for amazon.com
 func EssentialCookies() []string {
-	return []string{
-		"session-id",
-		"session-id-time",
-		"session-token",
-		"ubid-main",
-		"at-main",
-		"sess-at-main",
-		"sst-main",
-		"x-main",
-		"lc-main",
-		"i18n-prefs",
-	}
+	return MarketplaceUS.EssentialCookies()
 }
 
-// HasEssentialCookies checks if the store has the essential cookies for authentication
+// HasEssentialCookies checks if the store has the essential cookies for authentication with amazon.com
 func (s *CookieStore) HasEssentialCookies() bool {
-	essential := EssentialCookies()
-	minRequired := 4 // At minimum need session-id, session-token, ubid-main, at-main
+	return s.HasEssentialCookiesFor(MarketplaceUS)
+}
+
+// HasEssentialCookiesFor checks if the store has the essential cookies for authentication with a marketplace
+func (s *CookieStore) HasEssentialCookiesFor(m *Marketplace) bool {
+	essential := m.EssentialCookies()
+	minRequired := 4 // At minimum need session-id, session-token, ubid-*, at-*
 
 	count := 0
 	for _, name := range essential {
@@ -1,8 +1,10 @@
 package amazon
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 )
 
@@ -91,6 +93,35 @@ func TestCookieStore(t *testing.T) {
 	}
 }
 
+func TestCookieStore_ConcurrentSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	store, err := NewCookieStore(path)
+	if err != nil {
+		t.Fatalf("NewCookieStore failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			store.Set(&Cookie{Name: fmt.Sprintf("cookie-%d", i), Value: "value"})
+			if err := store.Save(); err != nil {
+				t.Errorf("Save failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	loaded, err := NewCookieStore(path)
+	if err != nil {
+		t.Fatalf("Saved cookie file doesn't load: %v", err)
+	}
+	if loaded.Count() != 20 {
+		t.Errorf("Expected 20 cookies, got %d", loaded.Count())
+	}
+}
+
 func TestCookieStore_ToHTTPCookies(t *testing.T) {
 	tmpDir := t.TempDir()
 	cookieFile := filepath.Join(tmpDir, "cookies.json")
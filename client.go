@@ -1,47 +1,68 @@
 package amazon
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
-	"sync"
 	"time"
+
+	"github.com/PuerkitoBio/goquery"
 )
 
 const (
-	baseURL           = "https://www.amazon.com"
-	ordersURL         = baseURL + "/your-orders/orders"
-	orderDetailsURL   = baseURL + "/your-orders/order-details"
-	transactionsURL   = baseURL + "/cpe/yourpayments/transactions"
-	defaultRateLimit  = 1 * time.Second
-	defaultTimeout    = 30 * time.Second
-	defaultMaxRetries = 3
+	ordersPath         = "/your-orders/orders"
+	orderDetailsPath   = "/your-orders/order-details"
+	transactionsPath   = "/cpe/yourpayments/transactions"
+	returnsPath        = "/spr/returns/history"
+	subscribeSavePath  = "/auto-deliveries/subscriptionList"
+	membershipsPath    = "/yourmembershipsandsubscriptions"
+	defaultRateLimit   = 1 * time.Second
+	defaultTimeout     = 30 * time.Second
+	defaultMaxRetries  = 3
+	defaultConcurrency = 1
 )
 
 // ClientConfig holds configuration options for the Amazon client
 type ClientConfig struct {
-	CookieFile  string
-	AccountName string // For multi-account support (e.g., "personal", "work")
-	RateLimit   time.Duration
-	MaxRetries  int
-	AutoSave    bool
-	Logger      *slog.Logger
-	UserAgent   string
+	CookieFile         string
+	AccountName        string // For multi-account support (e.g., "personal", "work")
+	RateLimit          time.Duration
+	Burst              int                            // Requests allowed at once before RateLimit applies
+	EndpointRateLimits map[PageType]EndpointRateLimit // Separate budgets per kind of page
+	Limiter            *Limiter                       // Shared limiter; overrides RateLimit, Burst and EndpointRateLimits
+	MaxRetries         int
+	RetryPolicy        RetryPolicy // Overrides MaxRetries
+	AutoSave           bool
+	Logger             *slog.Logger
+	UserAgent          string
+	PageSource         PageSource
+	Concurrency        int // Max order details/transactions fetched at once
+	HTTPClient         *http.Client
+	Transport          http.RoundTripper // Replaces the HTTP client's transport
+	BaseURL            string            // Defaults to the marketplace's site
+	Marketplace        *Marketplace      // Defaults to MarketplaceUS
+	Hooks              *Hooks            // Progress callbacks
 }
 
 // Client represents an Amazon client for fetching order data
 type Client struct {
 	httpClient  *http.Client
 	cookieStore *CookieStore
-	rateLimit   time.Duration
-	maxRetries  int
+	limiter     *Limiter
+	retryPolicy RetryPolicy
 	autoSave    bool
 	logger      *slog.Logger
 	userAgent   string
-	lastRequest time.Time
-	mu          sync.RWMutex
+	pageSource  PageSource
+	concurrency int
+	baseURL     string
+	marketplace *Marketplace
+	hooks       *Hooks
 }
 
 // Option is a function that configures the client
@@ -61,6 +82,34 @@ func WithRateLimit(d time.Duration) Option {
 	}
 }
 
+// WithBurst sets how many requests may be made at once before the rate limit applies
+func WithBurst(n int) Option {
+	return func(c *ClientConfig) {
+		c.Burst = n
+	}
+}
+
+// WithEndpointRateLimit sets a separate budget for one kind of page
+// (PageTypeOrderList, PageTypeOrderDetails, PageTypeTransactions,
+// PageTypeTracking, PageTypeReturns, PageTypeSubscriptions or
+// PageTypeInvoice), applied in addition to the global rate limit
+func WithEndpointRateLimit(pageType PageType, interval time.Duration, burst int) Option {
+	return func(c *ClientConfig) {
+		if c.EndpointRateLimits == nil {
+			c.EndpointRateLimits = make(map[PageType]EndpointRateLimit)
+		}
+		c.EndpointRateLimits[pageType] = EndpointRateLimit{Interval: interval, Burst: burst}
+	}
+}
+
+// WithLimiter shares a rate limiter between clients, e.g. several clients for
+// the same account. It overrides WithRateLimit, WithBurst and WithEndpointRateLimit.
+func WithLimiter(l *Limiter) Option {
+	return func(c *ClientConfig) {
+		c.Limiter = l
+	}
+}
+
 // WithMaxRetries sets the maximum number of retries for failed requests
 func WithMaxRetries(n int) Option {
 	return func(c *ClientConfig) {
@@ -68,6 +117,14 @@ func WithMaxRetries(n int) Option {
 	}
 }
 
+// WithRetryPolicy sets how failed requests are retried.
+// The default is exponential backoff with jitter limited by WithMaxRetries.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *ClientConfig) {
+		c.RetryPolicy = p
+	}
+}
+
 // WithAutoSave enables automatic cookie saving after each request
 func WithAutoSave(enabled bool) Option {
 	return func(c *ClientConfig) {
@@ -89,6 +146,64 @@ func WithUserAgent(ua string) Option {
 	}
 }
 
+// WithPageSource sets where order pages are fetched from.
+// By default pages are fetched live from Amazon over HTTP.
+func WithPageSource(src PageSource) Option {
+	return func(c *ClientConfig) {
+		c.PageSource = src
+	}
+}
+
+// WithConcurrency sets how many order details or transactions are fetched at once.
+// Requests still respect the client's rate limit; concurrency only overlaps
+// their network round trips.
+func WithConcurrency(n int) Option {
+	return func(c *ClientConfig) {
+		c.Concurrency = n
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests, e.g. to configure
+// proxies, TLS or timeouts. The client is used as-is; cookies are still
+// managed by the Amazon client rather than the HTTP client's jar.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *ClientConfig) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithTransport sets the RoundTripper used for requests, e.g. for
+// instrumentation. It applies to the client from WithHTTPClient if both are set.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *ClientConfig) {
+		c.Transport = rt
+	}
+}
+
+// WithMarketplace sets which regional Amazon site the client talks to.
+// It selects the domain, cookie names, currency and how prices and dates are parsed.
+func WithMarketplace(m *Marketplace) Option {
+	return func(c *ClientConfig) {
+		c.Marketplace = m
+	}
+}
+
+// WithBaseURL sets the Amazon site requests are sent to, e.g. a local fake
+// server in tests. It overrides the marketplace's domain.
+func WithBaseURL(u string) Option {
+	return func(c *ClientConfig) {
+		c.BaseURL = u
+	}
+}
+
+// WithHooks sets callbacks for progress events such as pages fetched,
+// retries and rate limit waits
+func WithHooks(hooks *Hooks) Option {
+	return func(c *ClientConfig) {
+		c.Hooks = hooks
+	}
+}
+
 // WithAccount sets the account name for multi-account support
 // Cookies will be stored in ~/.amazon-go/cookies-{accountName}.json
 func WithAccount(name string) Option {
@@ -101,10 +216,13 @@ func WithAccount(name string) Option {
 func NewClient(opts ...Option) (*Client, error) {
 	// Set defaults
 	config := &ClientConfig{
-		RateLimit:  defaultRateLimit,
-		MaxRetries: defaultMaxRetries,
-		AutoSave:   true,
-		UserAgent:  "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		RateLimit:   defaultRateLimit,
+		Burst:       1,
+		MaxRetries:  defaultMaxRetries,
+		Concurrency: defaultConcurrency,
+		AutoSave:    true,
+		Marketplace: MarketplaceUS,
+		UserAgent:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
 	}
 
 	// Apply options
@@ -139,24 +257,100 @@ func NewClient(opts ...Option) (*Client, error) {
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
-	// Create HTTP client with redirect handling
-	httpClient := &http.Client{
-		Timeout: defaultTimeout,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// Follow redirects but preserve cookies
-			return nil
-		},
+	if config.Marketplace == nil {
+		config.Marketplace = MarketplaceUS
+	}
+	if config.BaseURL == "" {
+		config.BaseURL = config.Marketplace.BaseURL()
 	}
 
-	return &Client{
+	baseURL, err := normalizeBaseURL(config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create HTTP client with redirect handling unless one was provided
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: defaultTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				// Follow redirects but preserve cookies
+				return nil
+			},
+		}
+	}
+	if config.Transport != nil {
+		// Copy so the caller's client isn't modified
+		hc := *httpClient
+		hc.Transport = config.Transport
+		httpClient = &hc
+	}
+
+	// Create rate limiter unless a shared one was provided
+	limiter := config.Limiter
+	if limiter == nil {
+		limiter = NewLimiter(NewRateLimiter(config.RateLimit, config.Burst))
+		for pageType, limit := range config.EndpointRateLimits {
+			limiter.SetEndpointLimit(pageType, NewRateLimiter(limit.Interval, limit.Burst))
+		}
+	}
+
+	client := &Client{
 		httpClient:  httpClient,
 		cookieStore: cookieStore,
-		rateLimit:   config.RateLimit,
-		maxRetries:  config.MaxRetries,
+		limiter:     limiter,
+		retryPolicy: config.RetryPolicy,
 		autoSave:    config.AutoSave,
 		logger:      logger.With("client", "amazon"),
 		userAgent:   config.UserAgent,
-	}, nil
+		pageSource:  config.PageSource,
+		concurrency: config.Concurrency,
+		baseURL:     baseURL,
+		marketplace: config.Marketplace,
+		hooks:       config.Hooks,
+	}
+
+	if client.retryPolicy == nil {
+		client.retryPolicy = DefaultRetryPolicy(config.MaxRetries)
+	}
+
+	// Default to fetching pages live over HTTP
+	if client.pageSource == nil {
+		client.pageSource = &httpPageSource{client: client}
+	}
+
+	return client, nil
+}
+
+// normalizeBaseURL validates a base URL and strips any trailing slash
+func normalizeBaseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("invalid base URL %q: must be an absolute http(s) URL", raw)
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// pageURL returns the absolute URL for a path on the client's Amazon site
+func (c *Client) pageURL(path string) string {
+	return c.baseURL + path
+}
+
+// Marketplace returns the regional Amazon site the client talks to
+func (c *Client) Marketplace() *Marketplace {
+	return c.marketplace
+}
+
+// newParser creates a parser for the client's marketplace whose links point
+// at the client's base URL
+func (c *Client) newParser() *Parser {
+	p := NewMarketplaceParser(c.marketplace)
+	p.baseURL = c.baseURL
+	return p
 }
 
 // CookieStore returns the cookie store for manual cookie management
@@ -166,16 +360,8 @@ func (c *Client) CookieStore() *CookieStore {
 
 // doRequest performs an HTTP request with rate limiting and retry logic
 func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
-	c.mu.Lock()
-	// Rate limiting
-	if !c.lastRequest.IsZero() {
-		elapsed := time.Since(c.lastRequest)
-		if elapsed < c.rateLimit {
-			time.Sleep(c.rateLimit - elapsed)
-		}
-	}
-	c.lastRequest = time.Now()
-	c.mu.Unlock()
+	ctx := req.Context()
+	pageType := pageTypeForURL(req.URL)
 
 	// Set headers
 	c.setHeaders(req)
@@ -185,40 +371,76 @@ func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 
 	var resp *http.Response
 	var err error
+	start := time.Now()
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		if attempt > 0 {
-			c.logger.Warn("retrying request",
-				"attempt", attempt,
-				"url", req.URL.String(),
-			)
-			time.Sleep(time.Duration(attempt) * time.Second)
+	for attempt := 1; ; attempt++ {
+		// Every attempt counts against the rate limit, retries included
+		waited, waitErr := c.limiter.wait(ctx, pageType)
+		if waitErr != nil {
+			return nil, fmt.Errorf("rate limit wait cancelled: %w", waitErr)
+		}
+		if waited > 0 {
+			c.hooks.rateLimitWait(RateLimitWaitEvent{URL: req.URL.String(), PageType: pageType, Wait: waited})
 		}
 
 		resp, err = c.httpClient.Do(req)
-		if err != nil {
-			continue
+
+		if err == nil {
+			if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
+				resp.Body.Close()
+				return nil, fmt.Errorf("authentication failed: cookies may be expired (status %d): %w", resp.StatusCode, ErrLoginRequired)
+			}
+
+			// Success
+			if resp.StatusCode < http.StatusBadRequest {
+				break
+			}
 		}
 
-		// Check for rate limiting or auth errors
-		if resp.StatusCode == http.StatusTooManyRequests {
-			c.logger.Warn("rate limited, waiting before retry")
-			resp.Body.Close()
-			time.Sleep(5 * time.Second)
-			continue
+		delay, retry := c.retryPolicy.NextRetry(RetryAttempt{
+			Attempt:  attempt,
+			Elapsed:  time.Since(start),
+			Request:  req,
+			Response: resp,
+			Err:      err,
+		})
+		if !retry {
+			if err != nil {
+				return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+			}
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+				resp.Body.Close()
+				return nil, fmt.Errorf("request failed after %d attempts: status %d", attempt, resp.StatusCode)
+			}
+			// Other client errors (e.g. 404) are left for the caller to handle,
+			// such as httpPageSource
+			break
 		}
 
-		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
+		if resp != nil {
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
 			resp.Body.Close()
-			return nil, fmt.Errorf("authentication failed: cookies may be expired (status %d)", resp.StatusCode)
 		}
 
-		// Success
-		break
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, err)
+		c.logger.Warn("retrying request",
+			"attempt", attempt,
+			"delay", delay,
+			"status", statusCode(resp),
+			"error", err,
+			"url", req.URL.String(),
+		)
+		c.hooks.retryScheduled(RetryEvent{
+			URL:        req.URL.String(),
+			Attempt:    attempt,
+			Delay:      delay,
+			StatusCode: statusCode(resp),
+			Err:        err,
+		})
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("request cancelled while waiting to retry: %w", err)
+		}
 	}
 
 	// Update cookies from response
@@ -226,7 +448,7 @@ func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 
 	// Auto-save cookies
 	if c.autoSave {
-		if err := c.cookieStore.Save(); err != nil {
+		if err := c.SaveCookies(); err != nil {
 			c.logger.Warn("failed to save cookies", "error", err)
 		}
 	}
@@ -234,11 +456,19 @@ func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// statusCode returns a response's status code, or 0 if there is no response
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
 // setHeaders sets common request headers
 func (c *Client) setHeaders(req *http.Request) {
 	headers := map[string]string{
 		"accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8",
-		"accept-language": "en-US,en;q=0.9",
+		"accept-language": c.marketplace.AcceptLanguage,
 		"cache-control":   "no-cache",
 		"pragma":          "no-cache",
 		"user-agent":      c.userAgent,
@@ -265,8 +495,8 @@ func (c *Client) setCookies(req *http.Request) {
 }
 
 // get performs a GET request to the given URL
-func (c *Client) get(url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -276,12 +506,17 @@ func (c *Client) get(url string) (*http.Response, error) {
 
 // HealthCheck verifies that the client can authenticate with Amazon
 func (c *Client) HealthCheck() error {
-	if !c.cookieStore.HasEssentialCookies() {
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is like HealthCheck but aborts the request when ctx is done
+func (c *Client) HealthCheckContext(ctx context.Context) error {
+	if !c.cookieStore.HasEssentialCookiesFor(c.marketplace) {
 		return fmt.Errorf("missing essential cookies: please import cookies from a browser session")
 	}
 
 	// Try to fetch the orders page
-	resp, err := c.get(ordersURL)
+	resp, err := c.get(ctx, c.pageURL(ordersPath))
 	if err != nil {
 		return fmt.Errorf("health check failed: %w", err)
 	}
@@ -292,20 +527,17 @@ func (c *Client) HealthCheck() error {
 	}
 
 	// Read body to check if we got the actual orders page or a login redirect
-	body, err := io.ReadAll(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return fmt.Errorf("health check failed: could not read response: %w", err)
 	}
 
-	bodyStr := string(body)
-
 	// Amazon returns 200 with login page when cookies are expired
-	// Check for login form fields (ap_email input) - NOT just /ap/signin which appears in nav
-	isLoginPage := (strings.Contains(bodyStr, "ap_email") || strings.Contains(bodyStr, "ap_password")) &&
-		!strings.Contains(bodyStr, "order-card")
-
-	if isLoginPage {
-		return fmt.Errorf("authentication failed: cookies are expired, please re-import cookies from browser")
+	switch err := detectBlockedPage(doc.Selection); {
+	case errors.Is(err, ErrLoginRequired):
+		return fmt.Errorf("authentication failed: cookies are expired, please re-import cookies from browser: %w", err)
+	case err != nil:
+		return fmt.Errorf("health check failed: %w", err)
 	}
 
 	return nil
@@ -313,7 +545,11 @@ func (c *Client) HealthCheck() error {
 
 // SaveCookies saves cookies to the file
 func (c *Client) SaveCookies() error {
-	return c.cookieStore.Save()
+	if err := c.cookieStore.Save(); err != nil {
+		return err
+	}
+	c.hooks.cookiesSaved(CookiesSavedEvent{Path: c.cookieStore.filePath, Cookies: c.cookieStore.Count()})
+	return nil
 }
 
 // ImportCookiesFromCurl imports cookies from a curl command string
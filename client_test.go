@@ -0,0 +1,323 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport counts requests passed to the wrapped transport
+type countingTransport struct {
+	next  http.RoundTripper
+	count int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.count, 1)
+	return t.next.RoundTrip(req)
+}
+
+// newFakeAmazon serves the order list and order details test pages
+func newFakeAmazon(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testOrderListPage))
+	})
+	mux.HandleFunc(orderDetailsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testOrderDetailsPage))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_BaseURLAndTransport(t *testing.T) {
+	server := newFakeAmazon(t)
+	transport := &countingTransport{next: http.DefaultTransport}
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL+"/"),
+		WithTransport(transport),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	orders, err := client.FetchOrders(context.Background(), FetchOptions{Year: 2025, IncludeDetails: true})
+	if err != nil {
+		t.Fatalf("FetchOrders failed: %v", err)
+	}
+	if len(orders) != 1 || orders[0].ID != "114-9733092-9360267" {
+		t.Fatalf("Expected order 114-9733092-9360267, got %+v", orders)
+	}
+	if transport.count != 2 {
+		t.Errorf("Expected 2 requests through the transport, got %d", transport.count)
+	}
+}
+
+func TestClient_BaseURLLinks(t *testing.T) {
+	server := newFakeAmazon(t)
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	list, err := client.FetchOrderSummaries(context.Background(), FetchOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("FetchOrderSummaries failed: %v", err)
+	}
+	if len(list.Summaries) != 1 {
+		t.Fatalf("Expected 1 summary, got %d", len(list.Summaries))
+	}
+	if detailURL := list.Summaries[0].DetailURL; !strings.HasPrefix(detailURL, server.URL+"/") {
+		t.Errorf("Expected detail URL on %s, got %s", server.URL, detailURL)
+	}
+}
+
+func TestClient_HTTPClientNotModified(t *testing.T) {
+	hc := &http.Client{}
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithHTTPClient(hc),
+		WithTransport(&countingTransport{next: http.DefaultTransport}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if hc.Transport != nil {
+		t.Error("Expected the caller's http.Client to be left unchanged")
+	}
+	if client.httpClient.Transport == nil {
+		t.Error("Expected the client to use the configured transport")
+	}
+}
+
+func TestNewClient_InvalidBaseURL(t *testing.T) {
+	for _, u := range []string{"www.amazon.com", "ftp://amazon.com", "://bad"} {
+		_, err := NewClient(
+			WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+			WithBaseURL(u),
+		)
+		if err == nil {
+			t.Errorf("Expected error for base URL %q", u)
+		}
+	}
+}
+
+const testTimeFilterPage = `<html><body>
+<form><select name="timeFilter" id="time-filter">
+	<option value="last30">last 30 days</option>
+	<option value="months-3" selected>past 3 months</option>
+	<option value="year-2025">2025</option>
+	<option value="year-2024">2024</option>
+	<option value="year-2022">2022</option>
+	<option value="archived">Archived Orders</option>
+</select></form>
+</body></html>`
+
+func TestParseTimeFilters(t *testing.T) {
+	filters, err := NewParser().ParseTimeFilters(strings.NewReader(testTimeFilterPage))
+	if err != nil {
+		t.Fatalf("ParseTimeFilters failed: %v", err)
+	}
+
+	expected := []TimeFilter{
+		{Value: "last30", Label: "last 30 days"},
+		{Value: "months-3", Label: "past 3 months"},
+		{Value: "year-2025", Label: "2025", Year: 2025},
+		{Value: "year-2024", Label: "2024", Year: 2024},
+		{Value: "year-2022", Label: "2022", Year: 2022},
+		{Value: "archived", Label: "Archived Orders"},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("ParseTimeFilters() = %+v, want %+v", filters, expected)
+	}
+}
+
+func TestClient_FetchOrdersTimeFilters(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	mux := http.NewServeMux()
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("timeFilter")
+		if filter == "" {
+			w.Write([]byte(testTimeFilterPage))
+			return
+		}
+		mu.Lock()
+		requested = append(requested, filter)
+		mu.Unlock()
+		w.Write([]byte(testOrderListPage))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	years, err := client.GetOrderYears(context.Background())
+	if err != nil {
+		t.Fatalf("GetOrderYears failed: %v", err)
+	}
+	if !reflect.DeepEqual(years, []int{2025, 2024, 2022}) {
+		t.Errorf("Expected years [2025 2024 2022], got %v", years)
+	}
+
+	tests := []struct {
+		name     string
+		opts     FetchOptions
+		expected []string
+	}{
+		{"every year in the dropdown", FetchOptions{}, []string{"year-2025", "year-2024", "year-2022"}},
+		{"years from the start date", FetchOptions{StartDate: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)}, []string{"year-2025", "year-2024"}},
+		{"explicit filter", FetchOptions{TimeFilter: "months-3", Year: 2020}, []string{"months-3"}},
+	}
+	for _, tc := range tests {
+		mu.Lock()
+		requested = nil
+		mu.Unlock()
+
+		if _, err := client.FetchOrders(context.Background(), tc.opts); err != nil {
+			t.Fatalf("%s: FetchOrders failed: %v", tc.name, err)
+		}
+
+		mu.Lock()
+		if !reflect.DeepEqual(requested, tc.expected) {
+			t.Errorf("%s: requested filters %v, want %v", tc.name, requested, tc.expected)
+		}
+		mu.Unlock()
+	}
+}
+
+// orderListPage builds an order list page with the given order IDs, an order
+// count if reported is set, and optionally a pagination control whose next
+// link is disabled when next is empty
+func orderListPage(ids []string, reported int, next string, pagination bool) string {
+	var b strings.Builder
+	b.WriteString("<html><body>")
+	if reported > 0 {
+		fmt.Fprintf(&b, `<span class="num-orders">%d orders</span> placed in 2025`, reported)
+	}
+	for _, id := range ids {
+		fmt.Fprintf(&b, `<div class="order-card">
+	<ul><li class="order-header__header-list-item">Order placed November 26, 2025</li>
+	<li class="order-header__header-list-item">Total $10.00</li></ul>
+	<a href="/your-orders/order-details?orderID=%s">View order details</a>
+</div>`, id)
+	}
+	if pagination {
+		b.WriteString(`<ul class="a-pagination"><li class="a-normal"><a href="#">1</a></li>`)
+		if next != "" {
+			fmt.Fprintf(&b, `<li class="a-last"><a href="%s">Next</a></li>`, next)
+		} else {
+			b.WriteString(`<li class="a-disabled a-last">Next</li>`)
+		}
+		b.WriteString("</ul>")
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
+
+func TestParseOrderListPage(t *testing.T) {
+	html := orderListPage([]string{"114-0000000-0000001", "114-0000000-0000002"}, 1234, "/your-orders/orders?timeFilter=year-2025&startIndex=2", true)
+	page, err := NewParser().ParseOrderListPage(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseOrderListPage failed: %v", err)
+	}
+	if len(page.Orders) != 2 || page.Cards != 2 || page.TotalOrders != 1234 {
+		t.Errorf("Unexpected page: %d orders, %d cards, total %d", len(page.Orders), page.Cards, page.TotalOrders)
+	}
+	if !page.HasPagination || page.NextURL != "/your-orders/orders?timeFilter=year-2025&startIndex=2" {
+		t.Errorf("Unexpected pagination: %v %q", page.HasPagination, page.NextURL)
+	}
+
+	last, err := NewParser().ParseOrderListPage(strings.NewReader(orderListPage([]string{"114-0000000-0000003"}, 0, "", true)))
+	if err != nil {
+		t.Fatalf("ParseOrderListPage failed: %v", err)
+	}
+	if !last.HasPagination || last.NextURL != "" || last.TotalOrders != 0 {
+		t.Errorf("Expected last page without next link, got %v %q %d", last.HasPagination, last.NextURL, last.TotalOrders)
+	}
+}
+
+func TestClient_FetchOrderSummariesPagination(t *testing.T) {
+	// Three orders per page, linked by the pagination control
+	paged := map[string]string{
+		"":  orderListPage([]string{"114-0000000-0000001", "114-0000000-0000002", "114-0000000-0000003"}, 7, "/your-orders/orders?timeFilter=year-2025&startIndex=3&ref_=next", true),
+		"3": orderListPage([]string{"114-0000000-0000004", "114-0000000-0000005", "114-0000000-0000006"}, 7, "/your-orders/orders?timeFilter=year-2025&startIndex=6&ref_=next", true),
+		"6": orderListPage([]string{"114-0000000-0000007"}, 7, "", true),
+	}
+	// Two orders per page without a pagination control; only the count tells when to stop
+	counted := map[string]string{
+		"":  orderListPage([]string{"114-0000000-0000011", "114-0000000-0000012"}, 3, "", false),
+		"2": orderListPage([]string{"114-0000000-0000013"}, 3, "", false),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) {
+		pages := paged
+		if r.URL.Query().Get("timeFilter") == "year-2024" {
+			pages = counted
+		}
+		page, ok := pages[r.URL.Query().Get("startIndex")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(page))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	for year, expected := range map[int]int{2025: 7, 2024: 3} {
+		list, err := client.FetchOrderSummaries(context.Background(), FetchOptions{Year: year})
+		if err != nil {
+			t.Fatalf("FetchOrderSummaries(%d) failed: %v", year, err)
+		}
+		if len(list.Summaries) != expected {
+			t.Errorf("%d: expected %d orders, got %d", year, expected, len(list.Summaries))
+		}
+		if len(list.Totals) != 1 || list.Totals[0].Reported != expected || !list.Totals[0].Complete() {
+			t.Errorf("%d: unexpected totals %+v", year, list.Totals)
+		}
+	}
+
+	if (OrderListTotal{Reported: 7, Collected: 6}).Complete() {
+		t.Error("Expected a list missing an order to be incomplete")
+	}
+}
@@ -0,0 +1,456 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	amazon "github.com/eshaffer321/amazon-go"
+)
+
+const dateLayout = "2006-01-02"
+
+// runAuthImport imports cookies from a curl command
+func runAuthImport(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+	var curlCmd string
+
+	fs := flag.NewFlagSet("auth import", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	fs.StringVar(&curlCmd, "curl", "", `Curl command copied from the browser ("-" reads it from stdin)`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if curlCmd == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read curl command: %w", err)
+		}
+		curlCmd = string(data)
+	}
+	if curlCmd == "" {
+		return fmt.Errorf("-curl is required")
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.ImportCookiesFromCurl(curlCmd); err != nil {
+		return fmt.Errorf("failed to import cookies: %w", err)
+	}
+
+	fmt.Fprintf(out, "Imported cookies (%d stored)\n", client.CookieStore().Count())
+	return nil
+}
+
+// runAuthStatus reports whether the stored cookies are usable
+func runAuthStatus(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+
+	fs := flag.NewFlagSet("auth status", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	store := client.CookieStore()
+	fmt.Fprintf(out, "Cookies stored:    %d\n", store.Count())
+
+	if !store.HasEssentialCookiesFor(client.Marketplace()) {
+		fmt.Fprintln(out, "Essential cookies: missing")
+		return fmt.Errorf("missing essential cookies: run amazon-go auth import -curl '<curl command>'")
+	}
+	fmt.Fprintln(out, "Essential cookies: present")
+
+	if err := client.HealthCheckContext(ctx); err != nil {
+		fmt.Fprintln(out, "Health check:      failed")
+		return err
+	}
+	fmt.Fprintln(out, "Health check:      ok")
+	return nil
+}
+
+// orderSourceOptions selects where orders come from and which ones are returned
+type orderSourceOptions struct {
+	year       int
+	timeFilter string
+	start      string
+	end        string
+	maxOrders  int
+	details    bool
+	digital    bool
+	savedPages string
+	dataExport string
+}
+
+// addOrderSourceFlags registers flags for selecting orders
+func addOrderSourceFlags(fs *flag.FlagSet, opts *orderSourceOptions, details bool) {
+	fs.IntVar(&opts.year, "year", 0, "Year to fetch orders from (default: every year with orders)")
+	fs.StringVar(&opts.timeFilter, "filter", "", "Order list time filter to fetch instead of a year, e.g. last30, months-3 or archived")
+	fs.StringVar(&opts.start, "start", "", "Only include orders on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&opts.end, "end", "", "Only include orders on or before this date (YYYY-MM-DD)")
+	fs.IntVar(&opts.maxOrders, "max", 0, "Maximum number of orders (0 = all)")
+	fs.BoolVar(&opts.details, "details", details, "Fetch full order details including items")
+	fs.BoolVar(&opts.digital, "digital", false, "Also fetch digital orders (Kindle, Prime Video, apps and music)")
+	fs.StringVar(&opts.savedPages, "saved-pages", "", "Parse a directory of saved HTML pages instead of fetching")
+	fs.StringVar(&opts.dataExport, "data-export", "", `Read a "Request My Data" export (ZIP, directory or CSV) instead of fetching`)
+}
+
+// fetchOptions converts the flags into library fetch options
+func (o *orderSourceOptions) fetchOptions() (amazon.FetchOptions, error) {
+	opts := amazon.FetchOptions{
+		Year:           o.year,
+		TimeFilter:     o.timeFilter,
+		MaxOrders:      o.maxOrders,
+		IncludeDetails: o.details,
+		IncludeDigital: o.digital,
+	}
+
+	var err error
+	if o.start != "" {
+		if opts.StartDate, err = time.Parse(dateLayout, o.start); err != nil {
+			return opts, fmt.Errorf("invalid -start date: %w", err)
+		}
+	}
+	if o.end != "" {
+		if opts.EndDate, err = time.Parse(dateLayout, o.end); err != nil {
+			return opts, fmt.Errorf("invalid -end date: %w", err)
+		}
+		// Include the whole end day
+		opts.EndDate = opts.EndDate.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return opts, nil
+}
+
+// loadOrders returns orders and any transactions known from the selected source
+func loadOrders(ctx context.Context, common *commonOptions, source *orderSourceOptions) ([]*amazon.Order, []*amazon.Transaction, error) {
+	opts, err := source.fetchOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case source.savedPages != "":
+		marketplace, err := common.getMarketplace()
+		if err != nil {
+			return nil, nil, err
+		}
+		orders, transactions, err := amazon.ParseSavedPages(source.savedPages, marketplace)
+		if err != nil {
+			return nil, nil, err
+		}
+		return filterOrders(orders, opts), transactions, nil
+
+	case source.dataExport != "":
+		data, err := amazon.ImportExport(source.dataExport)
+		if err != nil {
+			return nil, nil, err
+		}
+		return filterOrders(data.Orders, opts), data.Transactions, nil
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders, err := client.FetchOrders(ctx, opts)
+	var orderErrs amazon.OrderErrors
+	if errors.As(err, &orderErrs) {
+		// Orders whose details failed still have their list page data
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		err = nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return orders, nil, nil
+}
+
+// filterOrders applies date and count limits to orders read from a local source
+func filterOrders(orders []*amazon.Order, opts amazon.FetchOptions) []*amazon.Order {
+	var result []*amazon.Order
+	for _, order := range orders {
+		date := order.Date
+		if !date.IsZero() {
+			if opts.Year > 0 && date.Year() != opts.Year {
+				continue
+			}
+			if !opts.StartDate.IsZero() && date.Before(opts.StartDate) {
+				continue
+			}
+			if !opts.EndDate.IsZero() && date.After(opts.EndDate) {
+				continue
+			}
+		}
+
+		result = append(result, order)
+		if opts.MaxOrders > 0 && len(result) >= opts.MaxOrders {
+			break
+		}
+	}
+	return result
+}
+
+// runOrdersList lists orders
+func runOrdersList(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+	var source orderSourceOptions
+
+	fs := flag.NewFlagSet("orders list", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	addOrderSourceFlags(fs, &source, false)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	orders, _, err := loadOrders(ctx, &common, &source)
+	if err != nil {
+		return err
+	}
+
+	return writeOrders(out, common.format, orders)
+}
+
+// runOrdersShow shows a single order with its items
+func runOrdersShow(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+	var invoice bool
+
+	fs := flag.NewFlagSet("orders show", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	fs.BoolVar(&invoice, "invoice", false, "also read the invoice for sellers, item condition and addresses")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: amazon-go orders show [flags] <order-id>")
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	order, err := client.FetchOrder(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if invoice {
+		if _, err := client.FetchInvoice(ctx, order); err != nil {
+			return err
+		}
+	}
+
+	return writeOrder(out, common.format, order)
+}
+
+// runTransactions lists the payment transactions for an order
+func runTransactions(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+
+	fs := flag.NewFlagSet("transactions", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: amazon-go transactions [flags] <order-id>")
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	transactions, err := client.FetchTransactions(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return writeTransactions(out, common.format, transactions)
+}
+
+// runSubscriptions lists Subscribe & Save items and memberships
+func runSubscriptions(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+
+	fs := flag.NewFlagSet("subscriptions", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := client.FetchSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return writeSubscriptions(out, common.format, subscriptions)
+}
+
+// runExport writes orders with their items, one row per item in CSV
+func runExport(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+	var source orderSourceOptions
+	var output string
+
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	addOrderSourceFlags(fs, &source, true)
+	fs.StringVar(&output, "output", "", "Write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Table output is for humans; exports default to CSV
+	if !flagWasSet(fs, "format") {
+		common.format = formatCSV
+	}
+
+	orders, _, err := loadOrders(ctx, &common, &source)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		return writeOrderItems(out, common.format, orders)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeOrderItems(f, common.format, orders); err != nil {
+		f.Close()
+		return err
+	}
+	// A failed flush on close leaves the export truncated
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d orders to %s\n", len(orders), output)
+	return nil
+}
+
+// runParse prints the orders or transactions in a directory of saved pages
+func runParse(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+	var transactions bool
+
+	fs := flag.NewFlagSet("parse", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	fs.BoolVar(&transactions, "transactions", false, "List the payment transactions instead of the orders")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: amazon-go parse [flags] <dir>")
+	}
+
+	marketplace, err := common.getMarketplace()
+	if err != nil {
+		return err
+	}
+
+	orders, txs, err := amazon.ParseSavedPages(fs.Arg(0), marketplace)
+	if err != nil {
+		return err
+	}
+
+	if transactions {
+		return writeTransactions(out, common.format, txs)
+	}
+	return writeOrders(out, common.format, orders)
+}
+
+// runSync fetches new and changed orders into the local order store
+func runSync(ctx context.Context, args []string, out io.Writer) error {
+	var common commonOptions
+	var storeFile, start, end string
+	var year int
+	var transactions bool
+
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	addCommonFlags(fs, &common)
+	fs.StringVar(&storeFile, "store", "", "Path to order store file (default: ~/.amazon-go/orders[-account].json)")
+	fs.IntVar(&year, "year", 0, "Sync a specific year instead of changes since the last sync")
+	fs.StringVar(&start, "start", "", "Sync orders on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&end, "end", "", "Sync orders on or before this date (YYYY-MM-DD)")
+	fs.BoolVar(&transactions, "transactions", false, "Also fetch payment transactions for new and changed orders")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	source := orderSourceOptions{year: year, start: start, end: end}
+	fetchOpts, err := source.fetchOptions()
+	if err != nil {
+		return err
+	}
+
+	if storeFile == "" {
+		if common.account != "" {
+			storeFile, err = amazon.OrderStorePathForAccount(common.account)
+		} else {
+			storeFile, err = amazon.DefaultOrderStorePath()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	store, err := amazon.NewOrderStore(storeFile)
+	if err != nil {
+		return err
+	}
+
+	client, err := common.newClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.Sync(ctx, store, amazon.SyncOptions{
+		StartDate:           fetchOpts.StartDate,
+		EndDate:             fetchOpts.EndDate,
+		Year:                fetchOpts.Year,
+		IncludeTransactions: transactions,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Synced %s: %d new, %d updated, %d unchanged, %d failed (%d orders stored)\n",
+		storeFile, result.New, result.Updated, result.Unchanged, result.Failed, store.Count())
+	return nil
+}
+
+// flagWasSet reports whether a flag was given explicitly on the command line
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if strings.EqualFold(f.Name, name) {
+			set = true
+		}
+	})
+	return set
+}
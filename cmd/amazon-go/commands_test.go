@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSavedOrderList = `<html><body>
+<div class="order-card">
+	<ul><li class="order-header__header-list-item">Order placed November 26, 2025</li>
+	<li class="order-header__header-list-item">Total $44.91</li></ul>
+	<a href="/your-orders/order-details?orderID=114-9733092-9360267">View order details</a>
+</div>
+<div class="order-card">
+	<ul><li class="order-header__header-list-item">Order placed March 3, 2024</li>
+	<li class="order-header__header-list-item">Total $10.00</li></ul>
+	<a href="/your-orders/order-details?orderID=113-7382612-3141857">View order details</a>
+</div>
+</body></html>`
+
+// savedPagesDir writes an order list page to a temporary directory
+func savedPagesDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orders.html"), []byte(testSavedOrderList), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestOrderSourceFlags(t *testing.T) {
+	var source orderSourceOptions
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	addOrderSourceFlags(fs, &source, true)
+	if err := fs.Parse([]string{"-year", "2025", "-start", "2025-01-01", "-end", "2025-06-30", "-max", "5", "-digital"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	opts, err := source.fetchOptions()
+	if err != nil {
+		t.Fatalf("fetchOptions failed: %v", err)
+	}
+	if opts.Year != 2025 || opts.MaxOrders != 5 || !opts.IncludeDetails || !opts.IncludeDigital {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+	if !opts.StartDate.Equal(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected start date %v", opts.StartDate)
+	}
+	// The end date includes the whole day
+	if opts.EndDate.Format(dateLayout) != "2025-06-30" || opts.EndDate.Hour() != 23 {
+		t.Errorf("Expected end of day 2025-06-30, got %v", opts.EndDate)
+	}
+	if flagWasSet(fs, "format") || !flagWasSet(fs, "year") {
+		t.Error("flagWasSet doesn't match the parsed flags")
+	}
+
+	source = orderSourceOptions{start: "26/11/2025"}
+	if _, err := source.fetchOptions(); err == nil {
+		t.Error("Expected an error for an invalid -start date")
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"nope"}, &out); err == nil {
+		t.Error("Expected an error for an unknown command")
+	}
+	if err := run(context.Background(), []string{"orders", "nope"}, &out); err == nil {
+		t.Error("Expected an error for an unknown subcommand")
+	}
+	if err := run(context.Background(), []string{"help"}, &out); err != nil || !strings.Contains(out.String(), "Commands:") {
+		t.Errorf("Expected usage, got %v: %s", err, out.String())
+	}
+}
+
+func TestRunParse(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"parse", "-format", "csv", savedPagesDir(t)}, &out); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	records := readCSV(t, out.String())
+	if len(records) != 3 || records[1][0] != "114-9733092-9360267" {
+		t.Errorf("Unexpected output %v", records)
+	}
+
+	if err := run(context.Background(), []string{"parse"}, &out); err == nil {
+		t.Error("Expected an error without a directory")
+	}
+
+	// Saved amazon.de pages are read with German prices and dates
+	dir := t.TempDir()
+	page := `<html><body><div class="order-card">
+	<ul><li class="order-header__header-list-item">Bestellung aufgegeben 26. November 2025</li>
+	<li class="order-header__header-list-item">Summe 1.044,91 €</li></ul>
+	<a href="/your-orders/order-details?orderID=302-9733092-9360267">Bestelldetails anzeigen</a>
+</div></body></html>`
+	if err := os.WriteFile(filepath.Join(dir, "orders.html"), []byte(page), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := run(context.Background(), []string{"parse", "-marketplace", "de", "-format", "csv", dir}, &out); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	records = readCSV(t, out.String())
+	if len(records) != 2 || records[1][1] != "2025-11-26" || records[1][3] != "1044.91" || records[1][7] != "EUR" {
+		t.Errorf("Unexpected amazon.de output %v", records)
+	}
+}
+
+func TestRunExport(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "orders.csv")
+	args := []string{"export", "-saved-pages", savedPagesDir(t), "-year", "2025", "-output", output}
+	if err := run(context.Background(), args, &bytes.Buffer{}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Export file not written: %v", err)
+	}
+	// Exports default to CSV and -year drops the 2024 order
+	records := readCSV(t, string(data))
+	if len(records) != 2 || records[1][0] != "114-9733092-9360267" || records[1][2] != "44.91" {
+		t.Errorf("Unexpected export %v", records)
+	}
+
+	args = []string{"export", "-saved-pages", savedPagesDir(t), "-output", filepath.Join(t.TempDir(), "missing", "orders.csv")}
+	if err := run(context.Background(), args, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error when the output file can't be created")
+	}
+}
@@ -0,0 +1,172 @@
+// Command amazon-go fetches Amazon order history and payment transactions.
+//
+// Usage:
+//
+//	amazon-go auth import -curl '<curl command>'
+//	amazon-go auth status
+//	amazon-go orders list [-year 2025] [-details]
+//	amazon-go orders show <order-id>
+//	amazon-go transactions <order-id>
+//	amazon-go export [-output orders.csv]
+//	amazon-go parse [-transactions] <dir>
+//	amazon-go sync [-transactions]
+//
+// Every command accepts -account, -marketplace, -cookie-file, -rate-limit, -format and -verbose.
+// Flags must come before positional arguments.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	amazon "github.com/eshaffer321/amazon-go"
+)
+
+const usage = `amazon-go - Amazon order history from the command line
+
+Usage:
+  amazon-go <command> [flags] [args]
+
+Commands:
+  auth import      Import cookies from a "Copy as cURL" command
+  auth status      Check that stored cookies can reach the order history
+  orders list      List orders
+  orders show      Show a single order with its items
+  transactions     List payment transactions for an order
+  subscriptions    List Subscribe & Save items and memberships
+  export           Export orders with their items
+  parse            Parse a directory of saved order pages without fetching
+  sync             Incrementally sync orders into the local order store
+
+Run "amazon-go <command> -h" for command flags.
+`
+
+// commonOptions holds flags shared by every command
+type commonOptions struct {
+	account     string
+	marketplace string
+	cookieFile  string
+	rateLimit   time.Duration
+	concurrency int
+	format      string
+	verbose     bool
+}
+
+// addCommonFlags registers the shared flags on a command's flag set
+func addCommonFlags(fs *flag.FlagSet, opts *commonOptions) {
+	fs.StringVar(&opts.account, "account", "", "Account name for multi-account cookie storage")
+	fs.StringVar(&opts.marketplace, "marketplace", "us", "Amazon site: us, uk, de, ca or jp")
+	fs.StringVar(&opts.cookieFile, "cookie-file", "", "Path to cookie file (overrides -account)")
+	fs.DurationVar(&opts.rateLimit, "rate-limit", time.Second, "Minimum delay between requests")
+	fs.IntVar(&opts.concurrency, "concurrency", 1, "Number of order details or transactions fetched at once")
+	fs.StringVar(&opts.format, "format", formatTable, "Output format: table, json or csv")
+	fs.BoolVar(&opts.verbose, "verbose", false, "Enable verbose logging")
+}
+
+// getMarketplace returns the marketplace selected with -marketplace
+func (o *commonOptions) getMarketplace() (*amazon.Marketplace, error) {
+	return amazon.MarketplaceByID(o.marketplace)
+}
+
+// newClient creates a client from the shared flags
+func (o *commonOptions) newClient() (*amazon.Client, error) {
+	level := slog.LevelWarn
+	if o.verbose {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	marketplace, err := o.getMarketplace()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []amazon.Option{
+		amazon.WithMarketplace(marketplace),
+		amazon.WithLogger(logger),
+		amazon.WithRateLimit(o.rateLimit),
+		amazon.WithConcurrency(o.concurrency),
+	}
+	if o.account != "" {
+		opts = append(opts, amazon.WithAccount(o.account))
+	}
+	if o.cookieFile != "" {
+		opts = append(opts, amazon.WithCookieFile(o.cookieFile))
+	}
+
+	return amazon.NewClient(opts...)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches to the command named by args
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return flag.ErrHelp
+	}
+
+	cmd, rest := args[0], args[1:]
+	switch cmd {
+	case "auth":
+		return runGroup(ctx, "auth", rest, out, map[string]commandFunc{
+			"import": runAuthImport,
+			"status": runAuthStatus,
+		})
+	case "orders":
+		return runGroup(ctx, "orders", rest, out, map[string]commandFunc{
+			"list": runOrdersList,
+			"show": runOrdersShow,
+		})
+	case "transactions":
+		return runTransactions(ctx, rest, out)
+	case "subscriptions":
+		return runSubscriptions(ctx, rest, out)
+	case "export":
+		return runExport(ctx, rest, out)
+	case "parse":
+		return runParse(ctx, rest, out)
+	case "sync":
+		return runSync(ctx, rest, out)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q (run amazon-go help)", cmd)
+	}
+}
+
+// commandFunc runs a single command with its remaining arguments
+type commandFunc func(ctx context.Context, args []string, out io.Writer) error
+
+// runGroup dispatches to a subcommand of a command group such as "auth"
+func runGroup(ctx context.Context, group string, args []string, out io.Writer, commands map[string]commandFunc) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s requires a subcommand", group)
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q %q (run amazon-go help)", group, args[0])
+	}
+
+	return command(ctx, args[1:], out)
+}
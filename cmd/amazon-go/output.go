@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	amazon "github.com/eshaffer321/amazon-go"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// writeJSON writes v as indented JSON
+func writeJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeCSV writes a header and rows as CSV
+func writeCSV(out io.Writer, header []string, rows [][]string) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// writeTable writes a header and rows as aligned columns
+func writeTable(out io.Writer, header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	writeTableRow(w, header)
+	for _, row := range rows {
+		writeTableRow(w, row)
+	}
+	return w.Flush()
+}
+
+// writeTableRow writes a single tab-separated row
+func writeTableRow(w io.Writer, row []string) {
+	for i, cell := range row {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, cell)
+	}
+	fmt.Fprintln(w)
+}
+
+// writeRows writes rows in the given tabular format
+func writeRows(out io.Writer, format string, header []string, rows [][]string) error {
+	switch format {
+	case formatTable:
+		return writeTable(out, header, rows)
+	case formatCSV:
+		return writeCSV(out, header, rows)
+	default:
+		return fmt.Errorf("unknown format %q (want table, json or csv)", format)
+	}
+}
+
+// writeOrders writes one line per order
+func writeOrders(out io.Writer, format string, orders []*amazon.Order) error {
+	if format == formatJSON {
+		return writeJSON(out, orders)
+	}
+
+	header := []string{"Order ID", "Date", "Kind", "Total", "Subtotal", "Tax", "Shipping", "Currency", "Items"}
+	rows := make([][]string, 0, len(orders))
+	for _, order := range orders {
+		rows = append(rows, []string{
+			order.ID,
+			formatDate(order.Date),
+			string(order.Kind),
+			formatAmount(order.Total),
+			formatAmount(order.Subtotal),
+			formatAmount(order.Tax),
+			formatAmount(order.ShippingFees),
+			order.Total.Currency,
+			strconv.Itoa(len(order.Items)),
+		})
+	}
+
+	return writeRows(out, format, header, rows)
+}
+
+// writeOrder writes a single order and its items
+func writeOrder(out io.Writer, format string, order *amazon.Order) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(out, order)
+	case formatCSV:
+		return writeOrderItems(out, format, []*amazon.Order{order})
+	}
+
+	fmt.Fprintf(out, "Order ID: %s\n", order.ID)
+	fmt.Fprintf(out, "Date:     %s\n", formatDate(order.Date))
+	if len(order.Adjustments) > 0 {
+		// Full charge summary as shown on the order page
+		fmt.Fprintln(out)
+		for _, adj := range order.Adjustments {
+			fmt.Fprintf(out, "  %-32s %10s\n", adj.Label, formatAmount(adj.Amount))
+		}
+		if err := order.VerifyTotals(); err != nil {
+			fmt.Fprintf(out, "  Warning: %v\n", err)
+		}
+		fmt.Fprintln(out)
+	} else {
+		fmt.Fprintf(out, "Subtotal: %s\n", formatAmount(order.Subtotal))
+		fmt.Fprintf(out, "Tax:      %s\n", formatAmount(order.Tax))
+		fmt.Fprintf(out, "Shipping: %s\n", formatAmount(order.ShippingFees))
+		fmt.Fprintf(out, "Total:    %s\n\n", order.Total)
+	}
+
+	header := []string{"ASIN", "Name", "Qty", "Unit Price", "Price"}
+	rows := make([][]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		name := item.Name
+		if item.SubstituteFor != "" {
+			name += " (substitute for " + item.SubstituteFor + ")"
+		}
+		qty := strconv.FormatFloat(item.Quantity, 'f', -1, 64)
+		if item.Unit != "" {
+			qty += " " + item.Unit
+		}
+		rows = append(rows, []string{
+			item.ASIN,
+			name,
+			qty,
+			formatAmount(item.UnitPrice),
+			formatAmount(item.Price),
+		})
+	}
+
+	if err := writeTable(out, header, rows); err != nil {
+		return err
+	}
+
+	for i, shipment := range order.Shipments {
+		fmt.Fprintf(out, "\nShipment %d: %s\n", i+1, shipment.Status)
+		for _, item := range shipment.Items {
+			fmt.Fprintf(out, "  %s  %s\n", item.ASIN, item.Name)
+		}
+		if shipment.TrackingURL != "" {
+			fmt.Fprintf(out, "  Track: %s\n", shipment.TrackingURL)
+		}
+	}
+
+	for _, refund := range order.Refunds {
+		fmt.Fprintf(out, "\nRefund: %s %s %s\n", formatAmount(refund.Amount), formatDate(refund.Date), refund.Status)
+		for _, item := range refund.Items {
+			fmt.Fprintf(out, "  %s  %s\n", item.ASIN, item.Name)
+		}
+	}
+
+	if invoice := order.Invoice; invoice != nil {
+		fmt.Fprintln(out, "\nInvoice:")
+		for _, item := range order.Items {
+			if item.Seller != "" || item.Condition != "" {
+				fmt.Fprintf(out, "  %s  sold by %s, %s\n", item.Name, item.Seller, item.Condition)
+			}
+		}
+		if invoice.ShippingAddress != nil {
+			fmt.Fprintf(out, "  Shipped to: %s\n", invoice.ShippingAddress)
+		}
+		if invoice.BillingAddress != nil {
+			fmt.Fprintf(out, "  Billed to:  %s\n", invoice.BillingAddress)
+		}
+		if invoice.PaymentMethod != "" {
+			fmt.Fprintf(out, "  Payment:    %s\n", invoice.PaymentMethod)
+		}
+	}
+	return nil
+}
+
+// writeOrderItems writes one line per item, repeating order fields
+func writeOrderItems(out io.Writer, format string, orders []*amazon.Order) error {
+	if format == formatJSON {
+		return writeJSON(out, orders)
+	}
+
+	header := []string{"Order ID", "Date", "Order Total", "ASIN", "Name", "Qty", "Unit Price", "Price"}
+	var rows [][]string
+	for _, order := range orders {
+		orderFields := []string{order.ID, formatDate(order.Date), formatAmount(order.Total)}
+
+		// Orders without parsed items still get a row
+		if len(order.Items) == 0 {
+			rows = append(rows, append(orderFields, "", "", "", "", ""))
+			continue
+		}
+
+		for _, item := range order.Items {
+			row := append([]string{}, orderFields...)
+			rows = append(rows, append(row,
+				item.ASIN,
+				item.Name,
+				strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+				formatAmount(item.UnitPrice),
+				formatAmount(item.Price),
+			))
+		}
+	}
+
+	return writeRows(out, format, header, rows)
+}
+
+// writeTransactions writes one line per transaction
+func writeTransactions(out io.Writer, format string, transactions []*amazon.Transaction) error {
+	if format == formatJSON {
+		return writeJSON(out, transactions)
+	}
+
+	header := []string{"Order ID", "Date", "Amount", "Currency", "Payment Method", "Card Type", "Last Four", "Merchant", "Status"}
+	rows := make([][]string, 0, len(transactions))
+	for _, tx := range transactions {
+		rows = append(rows, []string{
+			tx.OrderID,
+			formatDate(tx.Date),
+			formatAmount(tx.Amount),
+			tx.Amount.Currency,
+			tx.PaymentMethod,
+			tx.CardType,
+			tx.LastFour,
+			tx.Merchant,
+			tx.Status,
+		})
+	}
+
+	return writeRows(out, format, header, rows)
+}
+
+// writeSubscriptions writes one line per subscription
+func writeSubscriptions(out io.Writer, format string, subscriptions []*amazon.Subscription) error {
+	if format == formatJSON {
+		return writeJSON(out, subscriptions)
+	}
+
+	header := []string{"Kind", "ASIN", "Name", "Qty", "Frequency", "Next Delivery", "Price", "Currency", "Discount %"}
+	rows := make([][]string, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		rows = append(rows, []string{
+			string(sub.Kind),
+			sub.ASIN,
+			sub.Name,
+			strconv.FormatFloat(sub.Quantity, 'f', -1, 64),
+			sub.Frequency,
+			formatDate(sub.NextDelivery),
+			formatAmount(sub.Price),
+			sub.Price.Currency,
+			strconv.FormatFloat(sub.DiscountPercent, 'f', -1, 64),
+		})
+	}
+
+	return writeRows(out, format, header, rows)
+}
+
+// formatDate formats a date as YYYY-MM-DD, or "" if unknown
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// formatAmount formats an amount in major units without its currency
+func formatAmount(amount amazon.Money) string {
+	return amount.Decimal()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	amazon "github.com/eshaffer321/amazon-go"
+)
+
+func testOrders() []*amazon.Order {
+	return []*amazon.Order{
+		{
+			ID:    "114-9733092-9360267",
+			Kind:  amazon.OrderKindPhysical,
+			Date:  time.Date(2025, time.November, 26, 0, 0, 0, 0, time.UTC),
+			Total: amazon.NewMoney(4491, "USD"),
+			Tax:   amazon.NewMoney(307, "USD"),
+			Items: []*amazon.OrderItem{
+				{ASIN: "B0CABLE001", Name: "USB-C Cable, 6 ft", Quantity: 2, UnitPrice: amazon.NewMoney(999, "USD"), Price: amazon.NewMoney(1998, "USD")},
+				{ASIN: "B0BOOK0001", Name: "The Example Handbook", Quantity: 1, UnitPrice: amazon.NewMoney(2186, "USD"), Price: amazon.NewMoney(2186, "USD")},
+			},
+		},
+		{ID: "113-7382612-3141857", Total: amazon.NewMoney(1000, "USD")},
+	}
+}
+
+func readCSV(t *testing.T, data string) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v\n%s", err, data)
+	}
+	return records
+}
+
+func TestWriteOrders_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeOrders(&buf, formatCSV, testOrders()); err != nil {
+		t.Fatalf("writeOrders failed: %v", err)
+	}
+
+	records := readCSV(t, buf.String())
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+	expected := []string{"114-9733092-9360267", "2025-11-26", "physical", "44.91", "0.00", "3.07", "0.00", "USD", "2"}
+	if strings.Join(records[1], ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected row %v, got %v", expected, records[1])
+	}
+	// Unknown dates are left blank
+	if records[2][1] != "" {
+		t.Errorf("Expected empty date, got %q", records[2][1])
+	}
+}
+
+func TestWriteOrders_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeOrders(&buf, formatJSON, testOrders()); err != nil {
+		t.Fatalf("writeOrders failed: %v", err)
+	}
+
+	var orders []*amazon.Order
+	if err := json.Unmarshal(buf.Bytes(), &orders); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if len(orders) != 2 || orders[0].Total != amazon.NewMoney(4491, "USD") || len(orders[0].Items) != 2 {
+		t.Errorf("Unexpected orders after round trip: %+v", orders)
+	}
+}
+
+func TestWriteOrders_UnknownFormat(t *testing.T) {
+	if err := writeOrders(&bytes.Buffer{}, "xml", testOrders()); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestWriteOrderItems_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeOrderItems(&buf, formatCSV, testOrders()); err != nil {
+		t.Fatalf("writeOrderItems failed: %v", err)
+	}
+
+	// One row per item, and a row for the order without items
+	records := readCSV(t, buf.String())
+	if len(records) != 4 {
+		t.Fatalf("Expected header and 3 rows, got %d records", len(records))
+	}
+	if records[1][3] != "B0CABLE001" || records[1][5] != "2" || records[1][7] != "19.98" {
+		t.Errorf("Unexpected item row %v", records[1])
+	}
+	if records[3][0] != "113-7382612-3141857" || records[3][3] != "" {
+		t.Errorf("Unexpected row for order without items %v", records[3])
+	}
+}
+
+func TestWriteTransactions(t *testing.T) {
+	transactions := []*amazon.Transaction{
+		{OrderID: "114-9733092-9360267", Amount: amazon.NewMoney(4491, "USD"), CardType: "Visa", LastFour: "1211", Status: "Completed"},
+		{OrderID: "114-9733092-9360267", Amount: amazon.NewMoney(-1999, "USD"), CardType: "Visa", LastFour: "1211", Status: "Refunded"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeTransactions(&buf, formatCSV, transactions); err != nil {
+		t.Fatalf("writeTransactions failed: %v", err)
+	}
+	records := readCSV(t, buf.String())
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+	if records[2][2] != "-19.99" || records[2][3] != "USD" || records[2][8] != "Refunded" {
+		t.Errorf("Unexpected refund row %v", records[2])
+	}
+
+	buf.Reset()
+	if err := writeTransactions(&buf, formatJSON, transactions); err != nil {
+		t.Fatalf("writeTransactions failed: %v", err)
+	}
+	var decoded []*amazon.Transaction
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if len(decoded) != 2 || decoded[1].Amount != amazon.NewMoney(-1999, "USD") {
+		t.Errorf("Unexpected transactions after round trip: %+v", decoded)
+	}
+}
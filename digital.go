@@ -0,0 +1,120 @@
+package amazon
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// OrderKind distinguishes physical orders from digital purchases
+type OrderKind string
+
+// Order kinds
+const (
+	OrderKindPhysical OrderKind = "physical" // Shipped goods, XXX-XXXXXXX-XXXXXXX order IDs
+	OrderKindDigital  OrderKind = "digital"  // Kindle, Prime Video, apps and music, D01-XXXXXXX-XXXXXXX order IDs
+)
+
+const (
+	// digitalOrderDetailsPath is the summary page for a digital order
+	digitalOrderDetailsPath = "/gp/digital/your-account/order-summary.html"
+
+	// itemLinkSelector matches product links for physical and digital items
+	itemLinkSelector = "a[href*='/dp/'], a[href*='/gp/product/'], a[href*='/gp/video/detail/']"
+)
+
+// isDigitalOrderID reports whether an order ID belongs to a digital order
+func isDigitalOrderID(id string) bool {
+	return strings.HasPrefix(id, "D")
+}
+
+// kindForOrderID returns the kind of order an ID belongs to, or "" if the ID is unknown
+func kindForOrderID(id string) OrderKind {
+	switch {
+	case id == "":
+		return ""
+	case isDigitalOrderID(id):
+		return OrderKindDigital
+	}
+	return OrderKindPhysical
+}
+
+// isDigitalOrderPage reports whether an order details page is a digital order
+// summary rather than the physical order page
+func isDigitalOrderPage(doc *goquery.Document) bool {
+	if doc.Find("#od-subtotals, [data-component='chargeSummary'], [data-component='shipments'], [data-component='shipmentsLeftGrid']").Length() > 0 {
+		return false
+	}
+	text := doc.Find("body").Text()
+	return strings.Contains(text, "Digital Order") || isDigitalOrderID(extractOrderIDFromText(text))
+}
+
+// orderPlacedDate reads the date from a short element like "Digital Order
+// Placed: November 26, 2025" or "Order Placed: ...", as shown on digital order
+// summaries and invoices
+func (p *Parser) orderPlacedDate(doc *goquery.Document) time.Time {
+	var date time.Time
+	doc.Find("td, span, b, div").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		idx := strings.Index(text, "Order Placed")
+		if idx < 0 || len(text) > 80 {
+			return true
+		}
+		dateText := strings.TrimPrefix(strings.TrimSpace(text[idx+len("Order Placed"):]), ":")
+		if d, err := p.marketplace.ParseDate(dateText); err == nil {
+			date = d
+			return false
+		}
+		return true
+	})
+	return date
+}
+
+// parseDigitalOrder reads a digital order summary page. It is a plain table
+// layout: one row per item with its price, then label/amount rows for the
+// charge summary.
+func (p *Parser) parseDigitalOrder(doc *goquery.Document, order *Order) {
+	order.Kind = OrderKindDigital
+	if order.ID == "" {
+		order.ID = extractOrderIDFromText(doc.Find("body").Text())
+	}
+
+	order.Date = p.orderPlacedDate(doc)
+
+	seen := make(map[string]bool)
+	doc.Find("tr").Each(func(i int, row *goquery.Selection) {
+		// Layout tables nest; only read the innermost rows
+		if row.Find("tr").Length() > 0 {
+			return
+		}
+		cells := row.ChildrenFiltered("td")
+		if cells.Length() < 2 {
+			return
+		}
+		valueText := strings.TrimSpace(cells.Last().Text())
+
+		if link := row.Find(itemLinkSelector).First(); link.Length() > 0 {
+			asin := extractASINFromURL(link.AttrOr("href", ""))
+			if asin == "" || seen[asin] {
+				return
+			}
+			seen[asin] = true
+
+			price, _ := p.marketplace.findPrice(valueText)
+			order.Items = append(order.Items, &OrderItem{
+				ASIN:      asin,
+				Name:      strings.Join(strings.Fields(link.Text()), " "),
+				Quantity:  1,
+				UnitPrice: price,
+				Price:     price,
+			})
+			return
+		}
+
+		label := strings.Join(strings.Fields(cells.First().Text()), " ")
+		if strings.HasSuffix(label, ":") && p.marketplace.hasCurrency(valueText) {
+			p.addAdjustment(order, label, valueText)
+		}
+	})
+}
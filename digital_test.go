@@ -0,0 +1,139 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDigitalOrderPage = `<html><body>
+<table><tr><td>
+	<table>
+		<tr><td><b>Digital Order: D01-1234567-7654321</b></td></tr>
+		<tr><td><b>Digital Order Placed:</b> November 20, 2025</td></tr>
+	</table>
+	<table>
+		<tr><th>Items Ordered</th><th>Price</th></tr>
+		<tr><td><a href="/dp/B0C1234567">The Example Novel (Kindle Edition)</a><br>Sold By: Amazon.com Services LLC</td><td>$9.99</td></tr>
+		<tr><td><a href="/gp/product/B0APP12345">Example Puzzle Game</a></td><td>$2.99</td></tr>
+	</table>
+	<table>
+		<tr><td>Item(s) Subtotal:</td><td>$12.98</td></tr>
+		<tr><td>Tax Collected:</td><td>$0.91</td></tr>
+		<tr><td>Grand Total:</td><td>$13.89</td></tr>
+	</table>
+</td></tr></table>
+</body></html>`
+
+const testDigitalOrderListPage = `<html><body>
+<div class="order-card">
+	<ul><li class="order-header__header-list-item">Order placed November 20, 2025</li>
+	<li class="order-header__header-list-item">Total $13.89</li></ul>
+	<a href="/gp/digital/your-account/order-summary.html?orderID=D01-1234567-7654321">View order details</a>
+</div>
+</body></html>`
+
+func TestParseOrderDetails_Digital(t *testing.T) {
+	order, err := NewParser().ParseOrderDetails(strings.NewReader(testDigitalOrderPage))
+	if err != nil {
+		t.Fatalf("ParseOrderDetails failed: %v", err)
+	}
+
+	if order.ID != "D01-1234567-7654321" || order.Kind != OrderKindDigital {
+		t.Errorf("Expected digital order D01-1234567-7654321, got %s (%s)", order.ID, order.Kind)
+	}
+	if order.Date.Format("2006-01-02") != "2025-11-20" {
+		t.Errorf("Expected date 2025-11-20, got %v", order.Date)
+	}
+	if order.Total.Decimal() != "13.89" || order.Tax.Decimal() != "0.91" || order.Subtotal.Decimal() != "12.98" {
+		t.Errorf("Unexpected totals: total=%s tax=%s subtotal=%s", order.Total, order.Tax, order.Subtotal)
+	}
+	if err := order.VerifyTotals(); err != nil {
+		t.Errorf("VerifyTotals failed: %v", err)
+	}
+
+	if len(order.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(order.Items))
+	}
+	book := order.Items[0]
+	if book.ASIN != "B0C1234567" || book.Name != "The Example Novel (Kindle Edition)" || book.Price.Decimal() != "9.99" {
+		t.Errorf("Unexpected first item: %s %q %s", book.ASIN, book.Name, book.Price)
+	}
+	if order.Items[1].ASIN != "B0APP12345" || order.Items[1].Price.Decimal() != "2.99" {
+		t.Errorf("Unexpected second item: %s %s", order.Items[1].ASIN, order.Items[1].Price)
+	}
+
+	// Physical order pages are unaffected
+	physical, err := NewParser().ParseOrderDetails(strings.NewReader(testOrderDetailsPage))
+	if err != nil {
+		t.Fatalf("ParseOrderDetails failed: %v", err)
+	}
+	if physical.Kind != OrderKindPhysical {
+		t.Errorf("Expected physical order, got %s", physical.Kind)
+	}
+}
+
+func TestClassifyPage_Digital(t *testing.T) {
+	pageType, err := NewParser().ClassifyPage(strings.NewReader(testDigitalOrderPage))
+	if err != nil {
+		t.Fatalf("ClassifyPage failed: %v", err)
+	}
+	if pageType != PageTypeOrderDetails {
+		t.Errorf("Expected %q, got %q", PageTypeOrderDetails, pageType)
+	}
+}
+
+func TestClient_FetchOrdersIncludeDigital(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("digitalOrders") == "1" {
+			w.Write([]byte(testDigitalOrderListPage))
+			return
+		}
+		w.Write([]byte(testOrderListPage))
+	})
+	mux.HandleFunc(orderDetailsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testOrderDetailsPage))
+	})
+	mux.HandleFunc(digitalOrderDetailsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testDigitalOrderPage))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	orders, err := client.FetchOrders(context.Background(), FetchOptions{Year: 2025, IncludeDetails: true, IncludeDigital: true})
+	if err != nil {
+		t.Fatalf("FetchOrders failed: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("Expected 2 orders, got %d", len(orders))
+	}
+	if orders[0].Kind != OrderKindPhysical || orders[1].Kind != OrderKindDigital {
+		t.Errorf("Expected physical then digital orders, got %s and %s", orders[0].Kind, orders[1].Kind)
+	}
+	if orders[1].ID != "D01-1234567-7654321" || orders[1].Total.Decimal() != "13.89" || len(orders[1].Items) != 2 {
+		t.Errorf("Unexpected digital order: %s total=%s items=%d", orders[1].ID, orders[1].Total, len(orders[1].Items))
+	}
+
+	// Digital orders are left out unless requested
+	orders, err = client.FetchOrders(context.Background(), FetchOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("FetchOrders failed: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Errorf("Expected 1 order without IncludeDigital, got %d", len(orders))
+	}
+}
@@ -0,0 +1,137 @@
+package amazon
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors. Use errors.Is to check for them, or errors.As with
+// *PageError for details of pages Amazon served instead of the expected content
+// and *TotalMismatchError for the amounts of an order that doesn't add up.
+var (
+	// ErrEncryptedContent means order data is encrypted client-side
+	// (SiegeClientSideDecryption) and must be decrypted in a browser first
+	ErrEncryptedContent = errors.New("page content is encrypted")
+
+	// ErrLoginRequired means Amazon served a sign-in page, usually because cookies expired
+	ErrLoginRequired = errors.New("login required")
+
+	// ErrCaptcha means Amazon served a CAPTCHA / robot check page
+	ErrCaptcha = errors.New("captcha challenge")
+
+	// ErrTotalMismatch means an order's charge summary rows don't add up to its grand total
+	ErrTotalMismatch = errors.New("order total mismatch")
+
+	// ErrCurrencyMismatch means amounts in different currencies were added or compared
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// TotalMismatchError reports an order whose subtotal, shipping, tax and
+// discounts don't add up to the grand total shown by Amazon
+type TotalMismatchError struct {
+	OrderID  string
+	Computed Money // Sum of the charge summary rows
+	Total    Money // Grand total shown on the order
+}
+
+// Error implements the error interface
+func (e *TotalMismatchError) Error() string {
+	return fmt.Sprintf("order %s: charges add up to %s but grand total is %s (off by %s)",
+		e.OrderID, e.Computed, e.Total, e.Total.Sub(e.Computed))
+}
+
+// Unwrap returns ErrTotalMismatch
+func (e *TotalMismatchError) Unwrap() error {
+	return ErrTotalMismatch
+}
+
+// PageError reports a page that could not be parsed because Amazon served
+// something other than the expected content
+type PageError struct {
+	PageType PageType // The kind of page that was expected
+	URL      string   // The page URL, if known
+	Err      error    // One of ErrEncryptedContent, ErrLoginRequired or ErrCaptcha
+}
+
+// Error implements the error interface
+func (e *PageError) Error() string {
+	if e.URL != "" {
+		return fmt.Sprintf("%s page %s: %v", e.PageType, e.URL, e.Err)
+	}
+	return fmt.Sprintf("%s page: %v", e.PageType, e.Err)
+}
+
+// Unwrap returns the underlying sentinel error
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// isBlockedPageError reports whether err means Amazon is not serving usable
+// pages, in which case retrying other pages will not help
+func isBlockedPageError(err error) bool {
+	return errors.Is(err, ErrEncryptedContent) ||
+		errors.Is(err, ErrLoginRequired) ||
+		errors.Is(err, ErrCaptcha)
+}
+
+// withPageURL records the page URL on a PageError in err's chain
+func withPageURL(err error, pageURL string) error {
+	var pageErr *PageError
+	if errors.As(err, &pageErr) && pageErr.URL == "" {
+		pageErr.URL = pageURL
+	}
+	return err
+}
+
+// OrderError records a failure for a single order in a batch fetch
+type OrderError struct {
+	OrderID string
+	Err     error
+}
+
+// Error implements the error interface
+func (e *OrderError) Error() string {
+	return fmt.Sprintf("order %s: %v", e.OrderID, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *OrderError) Unwrap() error {
+	return e.Err
+}
+
+// OrderErrors aggregates the per-order failures of a batch fetch.
+// Results for the orders that succeeded are still returned alongside it.
+type OrderErrors []*OrderError
+
+// Error implements the error interface
+func (e OrderErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d orders failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual order errors for errors.Is and errors.As
+func (e OrderErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// collectOrderErrors builds an OrderErrors from per-order results, or nil if none failed
+func collectOrderErrors(orderIDs []string, errs []error) error {
+	var result OrderErrors
+	for i, err := range errs {
+		if err != nil {
+			result = append(result, &OrderError{OrderID: orderIDs[i], Err: err})
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
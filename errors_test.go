@@ -0,0 +1,108 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testEncryptedOrderListPage = `<html><body>
+<div class="order-card js-order-card">
+	<div class="csd-encrypted-sensitive" id="csd-1">
+		<script>
+			SiegeClientSideDecryption.decryptInElementWithId("csd-1", {"ct": "S9XspR+u8Ori3uoQzMMh4k4SiVDD", "iv": "V5t1PF1IfzPo+xrD", "kid": "c3a22d"});
+		</script>
+	</div>
+</div>
+</body></html>`
+
+const testLoginPage = `<html><head><title>Amazon Sign-In</title></head><body>
+<a href="/ap/signin">Sign in</a>
+<form name="signIn" method="post"><input type="email" id="ap_email" name="email"></form>
+</body></html>`
+
+const testCaptchaPage = `<html><head><title>Robot Check</title></head><body>
+<form action="/errors/validateCaptcha"><input id="captchacharacters" name="field-keywords"></form>
+</body></html>`
+
+func TestParseOrderList_BlockedPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		wantErr error
+	}{
+		{"encrypted", testEncryptedOrderListPage, ErrEncryptedContent},
+		{"login", testLoginPage, ErrLoginRequired},
+		{"captcha", testCaptchaPage, ErrCaptcha},
+	}
+
+	parser := NewParser()
+	for _, tc := range tests {
+		_, err := parser.ParseOrderList(strings.NewReader(tc.html))
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("ParseOrderList(%s) error = %v, want %v", tc.name, err, tc.wantErr)
+		}
+
+		var pageErr *PageError
+		if !errors.As(err, &pageErr) || pageErr.PageType != PageTypeOrderList {
+			t.Errorf("ParseOrderList(%s) expected *PageError for order list, got %T", tc.name, err)
+		}
+	}
+}
+
+func TestParseOrderDetails_Blocked(t *testing.T) {
+	parser := NewParser()
+
+	_, err := parser.ParseOrderDetails(strings.NewReader(testLoginPage))
+	if !errors.Is(err, ErrLoginRequired) {
+		t.Errorf("Expected ErrLoginRequired, got %v", err)
+	}
+
+	_, err = parser.ParseOrderDetails(strings.NewReader(`<div class="csd-encrypted-sensitive"></div>`))
+	if !errors.Is(err, ErrEncryptedContent) {
+		t.Errorf("Expected ErrEncryptedContent, got %v", err)
+	}
+}
+
+func TestParseTransactions_Blocked(t *testing.T) {
+	parser := NewParser()
+
+	_, err := parser.ParseTransactions(strings.NewReader(testCaptchaPage))
+	if !errors.Is(err, ErrCaptcha) {
+		t.Errorf("Expected ErrCaptcha, got %v", err)
+	}
+}
+
+func TestFetchOrders_EncryptedReturnsError(t *testing.T) {
+	tmpDir := t.TempDir()
+	pageURL := "https://www.amazon.com/your-orders/orders?timeFilter=year-2025"
+	if err := os.WriteFile(filepath.Join(tmpDir, PageFileName(pageURL)), []byte(testEncryptedOrderListPage), 0600); err != nil {
+		t.Fatalf("failed to write page: %v", err)
+	}
+
+	src, err := NewDirPageSource(tmpDir)
+	if err != nil {
+		t.Fatalf("NewDirPageSource failed: %v", err)
+	}
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithPageSource(src),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	_, err = client.FetchOrders(context.Background(), FetchOptions{Year: 2025})
+	if !errors.Is(err, ErrEncryptedContent) {
+		t.Fatalf("Expected ErrEncryptedContent, got %v", err)
+	}
+
+	var pageErr *PageError
+	if !errors.As(err, &pageErr) || pageErr.URL != pageURL {
+		t.Errorf("Expected PageError with URL %s, got %v", pageURL, err)
+	}
+}
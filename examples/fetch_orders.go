@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -20,6 +21,7 @@ func main() {
 		verbose    bool
 		importCurl string
 		cookieFile string
+		offlineDir string
 	)
 
 	flag.IntVar(&year, "year", time.Now().Year(), "Year to fetch orders from")
@@ -28,6 +30,7 @@ func main() {
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
 	flag.StringVar(&importCurl, "import-curl", "", "Import cookies from a curl command")
 	flag.StringVar(&cookieFile, "cookie-file", "", "Path to cookie file")
+	flag.StringVar(&offlineDir, "offline-dir", "", "Parse orders from a directory of saved HTML pages instead of fetching")
 	flag.Parse()
 
 	// Setup logger
@@ -42,6 +45,17 @@ func main() {
 		}))
 	}
 
+	// Offline mode parses saved pages and never touches the network
+	if offlineDir != "" {
+		orders, transactions, err := amazon.ParseSavedPages(offlineDir, amazon.MarketplaceUS)
+		if err != nil {
+			log.Fatalf("Failed to parse saved pages: %v", err)
+		}
+		printOrders(orders)
+		fmt.Printf("Parsed %d transactions\n", len(transactions))
+		return
+	}
+
 	// Create client options
 	opts := []amazon.Option{
 		amazon.WithLogger(logger),
@@ -92,11 +106,21 @@ func main() {
 	}
 
 	orders, err := client.FetchOrders(ctx, fetchOpts)
+	var orderErrs amazon.OrderErrors
+	if errors.As(err, &orderErrs) {
+		// Orders whose details failed still have their list page data
+		log.Printf("Warning: %v", err)
+		err = nil
+	}
 	if err != nil {
 		log.Fatalf("Failed to fetch orders: %v", err)
 	}
 
-	// Display results
+	printOrders(orders)
+}
+
+// printOrders displays orders and a spending summary
+func printOrders(orders []*amazon.Order) {
 	fmt.Printf("\nFound %d orders:\n\n", len(orders))
 
 	for _, order := range orders {
@@ -113,7 +137,10 @@ func main() {
 			fmt.Printf("  Tax:      $%.2f\n", order.GetTax())
 		}
 		if order.GetFees() > 0 {
-			fmt.Printf("  Shipping: $%.2f\n", order.GetFees())
+			fmt.Printf("  Fees:     $%.2f\n", order.GetFees())
+		}
+		if order.GetTip() > 0 {
+			fmt.Printf("  Tip:      $%.2f\n", order.GetTip())
 		}
 
 		items := order.GetItems()
@@ -139,9 +166,9 @@ func main() {
 	}
 
 	// Summary
-	var total float64
+	var total amazon.Money
 	for _, order := range orders {
-		total += order.GetTotal()
+		total = total.Add(order.Total)
 	}
-	fmt.Printf("Total spent: $%.2f across %d orders\n", total, len(orders))
+	fmt.Printf("Total spent: %s across %d orders\n", total, len(orders))
 }
@@ -0,0 +1,534 @@
+package amazon
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CSVs read from an Amazon "Request My Data" export, e.g.
+// Retail.OrderHistory.1/Retail.OrderHistory.1.csv,
+// Retail.OrdersReturned.1/Retail.OrdersReturned.1.csv,
+// Retail.OrdersReturned.Payments.1/Retail.OrdersReturned.Payments.1.csv and
+// Digital-Ordering.1/Digital Items.csv
+var (
+	orderHistoryFilePattern   = regexp.MustCompile(`^Retail\.OrderHistory\.\d+\.csv$`)
+	returnsFilePattern        = regexp.MustCompile(`^Retail\.OrdersReturned\.\d+\.csv$`)
+	returnPaymentsFilePattern = regexp.MustCompile(`^Retail\.OrdersReturned\.Payments\.\d+\.csv$`)
+	digitalItemsFilePattern   = regexp.MustCompile(`^Digital Items\.csv$`)
+)
+
+// Column names used in export CSV files, lowercased without spaces so that
+// "Order ID", "OrderID" and "OrderId" all match
+const (
+	exportColWebsite       = "website"
+	exportColOrderID       = "orderid"
+	exportColOrderDate     = "orderdate"
+	exportColUnitPrice     = "unitprice"
+	exportColUnitPriceTax  = "unitpricetax"
+	exportColShipping      = "shippingcharge"
+	exportColTotalOwed     = "totalowed"
+	exportColASIN          = "asin"
+	exportColQuantity      = "quantity"
+	exportColPayment       = "paymentinstrumenttype"
+	exportColOrderStatus   = "orderstatus"
+	exportColShipDate      = "shipdate"
+	exportColProductName   = "productname"
+	exportColCurrency      = "currency"
+	exportNotAvailableText = "not available"
+
+	// Retail.OrdersReturned and Retail.OrdersReturned.Payments
+	exportColRefundDate   = "refundcompletiondate"
+	exportColRefundAmount = "amountrefunded"
+	exportColRefundStatus = "status"
+
+	// Digital Items
+	exportColTitle            = "title"
+	exportColQuantityOrdered  = "quantityordered"
+	exportColOurPrice         = "ourprice"
+	exportColOurPriceTax      = "ourpricetax"
+	exportColOurPriceCurrency = "ourpricecurrencycode"
+	exportColTransactionTotal = "transactionamount"
+	exportColMarketplace      = "marketplace"
+)
+
+// ExportData holds the orders and transactions read from an Amazon data export
+type ExportData struct {
+	Orders       []*Order
+	Transactions []*Transaction
+}
+
+// ImportExport reads an Amazon "Request My Data" export from path.
+// The path may be the export ZIP, a directory containing the extracted
+// export, or a single CSV file from it.
+//
+// Orders come from the Retail.OrderHistory and Digital Items CSVs; digital
+// orders have OrderKindDigital. Refunds in Retail.OrdersReturned are added to
+// their order's Refunds and as negative "Refunded" transactions.
+// Retail.OrdersReturned.Payments lists the same refunds by payment, so it
+// only fills in the refunded payment method and missing dates. Other files,
+// such as Retail.CustomerReturns, are not read.
+func ImportExport(path string) (*ExportData, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export: %w", err)
+	}
+
+	if info.IsDir() {
+		return importExportDir(path)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		r, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open export zip: %w", err)
+		}
+		defer r.Close()
+		return ImportExportZip(&r.Reader)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export: %w", err)
+	}
+	defer f.Close()
+
+	// Any other CSV name is read as order history
+	importer := newExportImporter()
+	add := importer.rowFunc(filepath.Base(path))
+	if add == nil {
+		add = importer.addRow
+	}
+	if err := importer.readCSV(f, add); err != nil {
+		return nil, err
+	}
+	return importer.result(), nil
+}
+
+// ImportExportZip reads the order history, digital items and refund CSVs
+// from an export ZIP archive
+func ImportExportZip(r *zip.Reader) (*ExportData, error) {
+	importer := newExportImporter()
+
+	for _, file := range r.File {
+		add := importer.rowFunc(filepath.Base(file.Name))
+		if add == nil {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		err = importer.readCSV(rc, add)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+	}
+
+	if !importer.foundOrders {
+		return nil, fmt.Errorf("no Retail.OrderHistory or Digital Items CSV found in export")
+	}
+
+	return importer.result(), nil
+}
+
+// importExportDir reads the export CSVs below an extracted export directory
+func importExportDir(dir string) (*ExportData, error) {
+	importer := newExportImporter()
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		add := importer.rowFunc(d.Name())
+		if add == nil {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if err := importer.readCSV(f, add); err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !importer.foundOrders {
+		return nil, fmt.Errorf("no Retail.OrderHistory or Digital Items CSV found in %s", dir)
+	}
+
+	return importer.result(), nil
+}
+
+// ParseOrderHistoryCSV parses a single Retail.OrderHistory CSV file.
+// Line rows are grouped by order ID into orders; tax and shipping are summed
+// across the rows of each order.
+func ParseOrderHistoryCSV(r io.Reader) (*ExportData, error) {
+	importer := newExportImporter()
+	if err := importer.readCSV(r, importer.addRow); err != nil {
+		return nil, err
+	}
+	return importer.result(), nil
+}
+
+// exportImporter accumulates rows across the CSV files of an export
+type exportImporter struct {
+	orders       map[string]*Order
+	transactions map[string]*Transaction
+	refunds      []*Refund
+	refundPays   []*Transaction // Retail.OrdersReturned.Payments rows, as refund transactions
+	foundOrders  bool           // An order history or digital items CSV was read
+}
+
+func newExportImporter() *exportImporter {
+	return &exportImporter{
+		orders:       make(map[string]*Order),
+		transactions: make(map[string]*Transaction),
+	}
+}
+
+// rowFunc returns the row handler for an export file name, or nil if the
+// file isn't one the importer reads
+func (e *exportImporter) rowFunc(name string) func(field func(string) string) {
+	switch {
+	case orderHistoryFilePattern.MatchString(name):
+		e.foundOrders = true
+		return e.addRow
+	case digitalItemsFilePattern.MatchString(name):
+		e.foundOrders = true
+		return e.addDigitalRow
+	case returnsFilePattern.MatchString(name):
+		return e.addRefundRow
+	case returnPaymentsFilePattern.MatchString(name):
+		return e.addRefundPaymentRow
+	}
+	return nil
+}
+
+// readCSV reads all rows of an export CSV, passing each to add
+func (e *exportImporter) readCSV(r io.Reader, add func(field func(string) string)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))] = i
+	}
+
+	if _, ok := columns[exportColOrderID]; !ok {
+		return fmt.Errorf("CSV is missing %q column", "Order ID")
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV row: %w", err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			value := strings.TrimSpace(record[i])
+			if strings.EqualFold(value, exportNotAvailableText) {
+				return ""
+			}
+			return value
+		}
+
+		add(field)
+	}
+
+	return nil
+}
+
+// addRow merges a single CSV line row into its order and transaction
+func (e *exportImporter) addRow(field func(string) string) {
+	orderID := field(exportColOrderID)
+	if orderID == "" {
+		return
+	}
+
+	order, ok := e.orders[orderID]
+	if !ok {
+		order = &Order{ID: orderID}
+		if date, err := parseExportDate(field(exportColOrderDate)); err == nil {
+			order.Date = date
+		}
+		e.orders[orderID] = order
+	}
+
+	// Export amounts are plain decimals in the row's currency
+	currency := field(exportColCurrency)
+	price := func(col string) Money {
+		return parseMoneyText(field(col), currency, false)
+	}
+
+	quantity := parseQuantity(field(exportColQuantity))
+	unitPrice := price(exportColUnitPrice)
+	lineTotal := unitPrice.Mul(quantity)
+
+	order.Items = append(order.Items, &OrderItem{
+		Name:      field(exportColProductName),
+		Price:     lineTotal,
+		Quantity:  quantity,
+		UnitPrice: unitPrice,
+		ASIN:      field(exportColASIN),
+	})
+
+	// Unit Price Tax is reported for the whole line, not per unit
+	order.Subtotal = order.Subtotal.Add(lineTotal)
+	order.Tax = order.Tax.Add(price(exportColUnitPriceTax))
+	order.ShippingFees = order.ShippingFees.Add(price(exportColShipping))
+
+	owed := price(exportColTotalOwed)
+	order.Total = order.Total.Add(owed)
+
+	tx, ok := e.transactions[orderID]
+	if !ok {
+		tx = &Transaction{
+			OrderID:  orderID,
+			Date:     order.Date,
+			Merchant: field(exportColWebsite),
+			Status:   field(exportColOrderStatus),
+		}
+		e.transactions[orderID] = tx
+	}
+	tx.Amount = tx.Amount.Add(owed)
+
+	if tx.PaymentMethod == "" {
+		if method := field(exportColPayment); method != "" {
+			tx.PaymentMethod = method
+			tx.CardType, tx.LastFour = parsePaymentMethod(method)
+		}
+	}
+
+	// Charges are made when items ship, so prefer the latest ship date
+	if shipDate, err := parseExportDate(field(exportColShipDate)); err == nil && shipDate.After(tx.Date) {
+		tx.Date = shipDate
+	}
+}
+
+// addDigitalRow merges a Digital Items row into its digital order
+func (e *exportImporter) addDigitalRow(field func(string) string) {
+	orderID := field(exportColOrderID)
+	if orderID == "" {
+		return
+	}
+
+	order, ok := e.orders[orderID]
+	if !ok {
+		order = &Order{ID: orderID, Kind: OrderKindDigital}
+		if date, err := parseExportDate(field(exportColOrderDate)); err == nil {
+			order.Date = date
+		}
+		e.orders[orderID] = order
+	}
+
+	currency := field(exportColOurPriceCurrency)
+	price := func(col string) Money {
+		return parseMoneyText(field(col), currency, false)
+	}
+
+	quantity := parseQuantity(field(exportColQuantityOrdered))
+	unitPrice := price(exportColOurPrice)
+	lineTotal := unitPrice.Mul(quantity)
+	tax := price(exportColOurPriceTax)
+
+	order.Items = append(order.Items, &OrderItem{
+		Name:      field(exportColTitle),
+		Price:     lineTotal,
+		Quantity:  quantity,
+		UnitPrice: unitPrice,
+		ASIN:      field(exportColASIN),
+	})
+	order.Subtotal = order.Subtotal.Add(lineTotal)
+	order.Tax = order.Tax.Add(tax)
+
+	// The amount charged is missing for free items and older rows
+	charged := price(exportColTransactionTotal)
+	if field(exportColTransactionTotal) == "" {
+		charged = lineTotal.Add(tax)
+	}
+	order.Total = order.Total.Add(charged)
+
+	tx, ok := e.transactions[orderID]
+	if !ok {
+		tx = &Transaction{
+			OrderID:  orderID,
+			Date:     order.Date,
+			Merchant: field(exportColMarketplace),
+		}
+		e.transactions[orderID] = tx
+	}
+	tx.Amount = tx.Amount.Add(charged)
+}
+
+// addRefundRow records a Retail.OrdersReturned row as a refund
+func (e *exportImporter) addRefundRow(field func(string) string) {
+	orderID := field(exportColOrderID)
+	amount := parseMoneyText(field(exportColRefundAmount), field(exportColCurrency), false)
+	if orderID == "" || amount.IsZero() {
+		return
+	}
+
+	refund := &Refund{
+		OrderID: orderID,
+		Amount:  amount,
+		Status:  field(exportColRefundStatus),
+	}
+	if date, err := parseExportDate(field(exportColRefundDate)); err == nil {
+		refund.Date = date
+	}
+	e.refunds = append(e.refunds, refund)
+}
+
+// addRefundPaymentRow records a Retail.OrdersReturned.Payments row. It
+// doesn't add a refund of its own, since the returns file lists it already.
+func (e *exportImporter) addRefundPaymentRow(field func(string) string) {
+	orderID := field(exportColOrderID)
+	amount := parseMoneyText(field(exportColRefundAmount), field(exportColCurrency), false)
+	if orderID == "" || amount.IsZero() {
+		return
+	}
+
+	tx := &Transaction{
+		OrderID: orderID,
+		Amount:  amount.Neg(),
+		Status:  "Refunded",
+	}
+	if method := field(exportColPayment); method != "" && !strings.EqualFold(method, exportNotAvailableText) {
+		tx.PaymentMethod = method
+		tx.CardType, tx.LastFour = parsePaymentMethod(method)
+	}
+	if date, err := parseExportDate(field(exportColRefundDate)); err == nil {
+		tx.Date = date
+	}
+	e.refundPays = append(e.refundPays, tx)
+}
+
+// refundPayment returns the first unused Retail.OrdersReturned.Payments row
+// for tx's order and amount, or nil
+func (e *exportImporter) refundPayment(tx *Transaction, used map[int]bool) *Transaction {
+	for i, pay := range e.refundPays {
+		if !used[i] && pay.OrderID == tx.OrderID && sameAmount(pay.Amount, tx.Amount) {
+			used[i] = true
+			return pay
+		}
+	}
+	return nil
+}
+
+// result returns the accumulated orders and transactions, newest first
+func (e *exportImporter) result() *ExportData {
+	data := &ExportData{
+		Orders:       make([]*Order, 0, len(e.orders)),
+		Transactions: make([]*Transaction, 0, len(e.transactions)),
+	}
+
+	for _, order := range e.orders {
+		data.Orders = append(data.Orders, order)
+	}
+	sort.SliceStable(data.Orders, func(i, j int) bool {
+		if data.Orders[i].Date.Equal(data.Orders[j].Date) {
+			return data.Orders[i].ID < data.Orders[j].ID
+		}
+		return data.Orders[i].Date.After(data.Orders[j].Date)
+	})
+
+	// Refund files may be read before the orders they belong to
+	refundTransactions := make(map[string][]*Transaction)
+	usedPays := make(map[int]bool)
+	for _, refund := range e.refunds {
+		if order, ok := e.orders[refund.OrderID]; ok {
+			order.Refunds = append(order.Refunds, refund)
+		}
+		tx := &Transaction{
+			OrderID: refund.OrderID,
+			Date:    refund.Date,
+			Amount:  refund.Amount.Neg(),
+			Status:  "Refunded",
+		}
+		if charge, ok := e.transactions[refund.OrderID]; ok {
+			tx.PaymentMethod, tx.CardType, tx.LastFour = charge.PaymentMethod, charge.CardType, charge.LastFour
+			tx.Merchant = charge.Merchant
+		}
+		if pay := e.refundPayment(tx, usedPays); pay != nil {
+			if pay.PaymentMethod != "" {
+				tx.PaymentMethod, tx.CardType, tx.LastFour = pay.PaymentMethod, pay.CardType, pay.LastFour
+			}
+			if tx.Date.IsZero() {
+				tx.Date = pay.Date
+				refund.Date = pay.Date
+			}
+		}
+		refundTransactions[refund.OrderID] = append(refundTransactions[refund.OrderID], tx)
+	}
+
+	for _, order := range data.Orders {
+		data.Transactions = append(data.Transactions, e.transactions[order.ID])
+		data.Transactions = append(data.Transactions, refundTransactions[order.ID]...)
+		delete(refundTransactions, order.ID)
+	}
+
+	// Refunds for orders outside the export's order files
+	for _, refund := range e.refunds {
+		if txs, ok := refundTransactions[refund.OrderID]; ok {
+			data.Transactions = append(data.Transactions, txs...)
+			delete(refundTransactions, refund.OrderID)
+		}
+	}
+
+	return data
+}
+
+// parseExportDate parses the date formats used in Amazon data exports
+func parseExportDate(text string) (time.Time, error) {
+	text = strings.TrimSpace(text)
+
+	formats := []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05 MST",
+		"01/02/2006 15:04:05 MST",
+		"01/02/2006",
+		"2006-01-02",
+	}
+
+	for _, format := range formats {
+		if t, err := time.Parse(format, text); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse export date: %s", text)
+}
@@ -0,0 +1,216 @@
+package amazon
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testOrderHistoryCSV = `"Website","Order ID","Order Date","Purchase Order Number","Currency","Unit Price","Unit Price Tax","Shipping Charge","Total Discounts","Total Owed","Shipment Item Subtotal","Shipment Item Subtotal Tax","ASIN","Product Condition","Quantity","Payment Instrument Type","Order Status","Shipment Status","Ship Date","Shipping Option","Shipping Address","Billing Address","Carrier Name & Tracking Number","Product Name","Gift Message","Gift Sender Name","Gift Recipient Contact Details","Item Serial Number"
+"Amazon.com","114-9733092-9360267","2025-11-26T18:22:33Z","Not Applicable","USD","19.99","1.40","0","0","21.39","19.99","1.40","B09XV8WDY6","New","1","Prime Visa ****1211","Closed","Shipped","2025-11-27T10:00:00Z","standard","Jane Doe 1 Main St","Jane Doe 1 Main St","AMZN_US(TBA123)","USB-C Cable","Not Available","Not Available","Not Available","Not Available"
+"Amazon.com","114-9733092-9360267","2025-11-26T18:22:33Z","Not Applicable","USD","10.00","1.50","4.99","0","26.49","20.00","1.50","B0FJDMHXD1","New","2","Prime Visa ****1211","Closed","Shipped","2025-11-28T10:00:00Z","standard","Jane Doe 1 Main St","Jane Doe 1 Main St","AMZN_US(TBA124)","Dish Soap","Not Available","Not Available","Not Available","Not Available"
+"Amazon.com","113-7382612-3141857","2025-10-01T08:00:00.000Z","Not Applicable","USD","1,234.00","0","Not Available","0","1,234.00","1234.00","0","B0D6VC4PM6","New","1","Mastercard ****5678","Closed","Shipped","2025-10-02T10:00:00Z","standard","Jane Doe 1 Main St","Jane Doe 1 Main St","AMZN_US(TBA125)","Laptop","Not Available","Not Available","Not Available","Not Available"
+`
+
+func TestParseOrderHistoryCSV(t *testing.T) {
+	data, err := ParseOrderHistoryCSV(strings.NewReader(testOrderHistoryCSV))
+	if err != nil {
+		t.Fatalf("ParseOrderHistoryCSV failed: %v", err)
+	}
+
+	if len(data.Orders) != 2 {
+		t.Fatalf("Expected 2 orders, got %d", len(data.Orders))
+	}
+
+	// Orders are returned newest first
+	order := data.Orders[0]
+	if order.ID != "114-9733092-9360267" {
+		t.Errorf("Expected first order 114-9733092-9360267, got %s", order.ID)
+	}
+	if len(order.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(order.Items))
+	}
+	if order.Items[1].Quantity != 2 || order.Items[1].Price.Decimal() != "20.00" {
+		t.Errorf("Unexpected second item: qty=%.0f price=%s", order.Items[1].Quantity, order.Items[1].Price)
+	}
+	if order.Subtotal.Decimal() != "39.99" {
+		t.Errorf("Expected subtotal 39.99, got %s", order.Subtotal)
+	}
+	if order.Tax.Decimal() != "2.90" {
+		t.Errorf("Expected tax 2.90, got %s", order.Tax)
+	}
+	if order.ShippingFees.Decimal() != "4.99" {
+		t.Errorf("Expected shipping 4.99, got %s", order.ShippingFees)
+	}
+	if order.Total.Decimal() != "47.88" {
+		t.Errorf("Expected total 47.88, got %s", order.Total)
+	}
+	if order.Date.Format("2006-01-02") != "2025-11-26" {
+		t.Errorf("Unexpected order date: %v", order.Date)
+	}
+
+	if len(data.Transactions) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(data.Transactions))
+	}
+	tx := data.Transactions[0]
+	if tx.OrderID != order.ID || tx.Amount.Decimal() != "47.88" {
+		t.Errorf("Unexpected transaction: order=%s amount=%s", tx.OrderID, tx.Amount)
+	}
+	if tx.CardType != "Visa" || tx.LastFour != "1211" {
+		t.Errorf("Unexpected payment method: %s %s", tx.CardType, tx.LastFour)
+	}
+	if tx.Date.Format("2006-01-02") != "2025-11-28" {
+		t.Errorf("Expected transaction date from last ship date, got %v", tx.Date)
+	}
+	if tx.Merchant != "Amazon.com" {
+		t.Errorf("Expected merchant Amazon.com, got %s", tx.Merchant)
+	}
+
+	if data.Orders[1].Total.Decimal() != "1234.00" {
+		t.Errorf("Expected total 1234.00, got %s", data.Orders[1].Total)
+	}
+}
+
+func TestParseOrderHistoryCSV_MissingOrderID(t *testing.T) {
+	_, err := ParseOrderHistoryCSV(strings.NewReader("Website,Product Name\nAmazon.com,Widget\n"))
+	if err == nil {
+		t.Error("Expected error for CSV without Order ID column")
+	}
+}
+
+func TestImportExport_Zip(t *testing.T) {
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "Your Orders.zip")
+
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("failed to create zip: %v", err)
+	}
+	w := zip.NewWriter(f)
+	files := map[string]string{
+		"Retail.OrderHistory.1/Retail.OrderHistory.1.csv": testOrderHistoryCSV,
+		"Retail.OrderHistory.1/README.txt":                "ignored",
+	}
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+		fw.Write([]byte(content))
+	}
+	w.Close()
+	f.Close()
+
+	data, err := ImportExport(zipPath)
+	if err != nil {
+		t.Fatalf("ImportExport failed: %v", err)
+	}
+	if len(data.Orders) != 2 {
+		t.Errorf("Expected 2 orders, got %d", len(data.Orders))
+	}
+}
+
+func TestImportExport_Dir(t *testing.T) {
+	tmpDir := t.TempDir()
+	csvDir := filepath.Join(tmpDir, "Retail.OrderHistory.1")
+	if err := os.MkdirAll(csvDir, 0700); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(csvDir, "Retail.OrderHistory.1.csv"), []byte(testOrderHistoryCSV), 0600); err != nil {
+		t.Fatalf("failed to write csv: %v", err)
+	}
+
+	data, err := ImportExport(tmpDir)
+	if err != nil {
+		t.Fatalf("ImportExport failed: %v", err)
+	}
+	if len(data.Orders) != 2 {
+		t.Errorf("Expected 2 orders, got %d", len(data.Orders))
+	}
+
+	if _, err := ImportExport(t.TempDir()); err == nil {
+		t.Error("Expected error for directory without order history")
+	}
+}
+
+const testReturnsCSV = `"OrderID","ReversalID","RefundCompletionDate","Currency","AmountRefunded","Status","DisbursementType"
+"114-9733092-9360267","R1","2025-12-05T12:00:00Z","USD","21.39","Completed","Refund"
+"112-0000000-0000000","R2","2024-01-03T12:00:00Z","USD","5.00","Completed","Refund"
+`
+
+// The payments file lists the same refunds by payment method, plus a row
+// without a matching return
+const testReturnPaymentsCSV = `"OrderID","RefundCompletionDate","Currency","AmountRefunded","PaymentInstrumentType"
+"114-9733092-9360267","2025-12-05T12:00:00Z","USD","21.39","Mastercard ****4242"
+"112-0000000-0000000","2024-01-03T12:00:00Z","USD","5.00","Not Available"
+"113-0000000-0000000","2024-02-01T12:00:00Z","USD","8.00","Visa ****1111"
+`
+
+const testDigitalItemsCSV = `"ASIN","Title","OrderId","DigitalOrderItemId","OrderDate","QuantityOrdered","OurPrice","OurPriceTax","OurPriceCurrencyCode","Marketplace","TransactionAmount"
+"B0KINDLE01","Example Novel","D01-1234567-1234567","1","2025-12-01T09:00:00Z","1","9.99","0.70","USD","Amazon.com","10.69"
+"B0SONG0001","Example Song","D01-7654321-7654321","2","2025-06-01T09:00:00Z","1","0.00","0.00","USD","Amazon.com","Not Available"
+`
+
+func TestImportExport_ReturnsAndDigital(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"Retail.OrderHistory.1/Retail.OrderHistory.1.csv":                       testOrderHistoryCSV,
+		"Retail.OrdersReturned.1/Retail.OrdersReturned.1.csv":                   testReturnsCSV,
+		"Retail.OrdersReturned.Payments.1/Retail.OrdersReturned.Payments.1.csv": testReturnPaymentsCSV,
+		"Digital-Ordering.1/Digital Items.csv":                                  testDigitalItemsCSV,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	data, err := ImportExport(tmpDir)
+	if err != nil {
+		t.Fatalf("ImportExport failed: %v", err)
+	}
+	if len(data.Orders) != 4 {
+		t.Fatalf("Expected 2 retail and 2 digital orders, got %d", len(data.Orders))
+	}
+
+	// Digital orders sort by date with the others
+	novel := data.Orders[0]
+	if novel.ID != "D01-1234567-1234567" || novel.Kind != OrderKindDigital || len(novel.Items) != 1 {
+		t.Fatalf("Expected digital order first, got %s (%s)", novel.ID, novel.Kind)
+	}
+	if novel.Total.Decimal() != "10.69" || novel.Tax.Decimal() != "0.70" || novel.Items[0].Name != "Example Novel" {
+		t.Errorf("Unexpected digital order: total=%s tax=%s item=%q", novel.Total, novel.Tax, novel.Items[0].Name)
+	}
+
+	retail := data.Orders[1]
+	if retail.ID != "114-9733092-9360267" || len(retail.Refunds) != 1 || retail.Refunds[0].Amount.Decimal() != "21.39" {
+		t.Fatalf("Expected a 21.39 refund on 114-9733092-9360267, got %+v", retail.Refunds)
+	}
+
+	var refunds []*Transaction
+	for _, tx := range data.Transactions {
+		if tx.Status == "Refunded" {
+			refunds = append(refunds, tx)
+		}
+	}
+	// Payments rows add detail to the returns but no refunds of their own
+	if len(refunds) != 2 {
+		t.Fatalf("Expected 2 refund transactions, got %d", len(refunds))
+	}
+	if refunds[0].OrderID != retail.ID || refunds[0].Amount != NewMoney(-2139, "USD") || refunds[0].CardType != "Mastercard" || refunds[0].LastFour != "4242" {
+		t.Errorf("Unexpected refund transaction %+v", refunds[0])
+	}
+	if refunds[0].Date.Format("2006-01-02") != "2025-12-05" {
+		t.Errorf("Expected refund date 2025-12-05, got %v", refunds[0].Date)
+	}
+	// Refunds for orders outside the export are still reported
+	if refunds[1].OrderID != "112-0000000-0000000" || refunds[1].Amount != NewMoney(-500, "USD") {
+		t.Errorf("Unexpected refund transaction %+v", refunds[1])
+	}
+}
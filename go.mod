@@ -2,9 +2,9 @@ module github.com/eshaffer321/amazon-go
 
 go 1.21
 
-require github.com/PuerkitoBio/goquery v1.8.1
-
 require (
-	github.com/andybalholm/cascadia v1.3.1 // indirect
-	golang.org/x/net v0.7.0 // indirect
+	github.com/PuerkitoBio/goquery v1.8.1
+	golang.org/x/net v0.7.0
 )
+
+require github.com/andybalholm/cascadia v1.3.1 // indirect
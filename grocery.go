@@ -0,0 +1,118 @@
+package amazon
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Grocery order kinds
+const (
+	OrderKindFresh      OrderKind = "fresh"       // Amazon Fresh delivery or pickup
+	OrderKindWholeFoods OrderKind = "whole_foods" // Whole Foods Market delivery or pickup
+	OrderKindInStore    OrderKind = "in_store"    // Purchases at an Amazon Fresh, Whole Foods or Amazon Go store
+)
+
+// groceryItemSelector matches item rows on Fresh and Whole Foods order pages
+const groceryItemSelector = "[data-component='groceryItem'], .grocery-item"
+
+var (
+	// "1.52 lb", "Weight: 0.8 kg"
+	weightPattern = regexp.MustCompile(`(?i)(\d+(?:[.,]\d+)?)\s*(lbs?|kg|g|oz)\b`)
+	// "Substituted for Strawberries, 1 lb", "Replaced: ...", "Instead of ..."
+	substitutionPattern = regexp.MustCompile(`(?i)(?:substitut\w*(?:\s+for)?|replac\w*(?:\s+for)?|instead of)\s*:?\s*(.+)`)
+)
+
+// isGroceryOrderPage reports whether an order details page is a Fresh,
+// Whole Foods or in-store order rather than a shipped order
+func isGroceryOrderPage(doc *goquery.Document) bool {
+	return doc.Find(groceryItemSelector).Length() > 0
+}
+
+// groceryOrderKind tells Fresh, Whole Foods and in-store orders apart from the page text
+func groceryOrderKind(text string) OrderKind {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "in-store") || strings.Contains(lower, "in store") || strings.Contains(lower, "store purchase"):
+		return OrderKindInStore
+	case strings.Contains(lower, "whole foods"):
+		return OrderKindWholeFoods
+	}
+	return OrderKindFresh
+}
+
+// parseGroceryOrder reads a Fresh, Whole Foods or in-store order page. Items
+// may be sold by weight, substituted for what was ordered or unavailable, and
+// the charge summary can include a driver tip and bag fees.
+func (p *Parser) parseGroceryOrder(doc *goquery.Document, order *Order) {
+	header := doc.Find("[data-component='orderHeader'], .order-header, h1").First().Text()
+	order.Kind = groceryOrderKind(header)
+
+	p.parseOrderSummary(doc, order)
+
+	doc.Find(groceryItemSelector).Each(func(i int, row *goquery.Selection) {
+		if item := p.parseGroceryItem(row); item != nil {
+			order.Items = append(order.Items, item)
+		}
+	})
+}
+
+// parseGroceryItem reads one grocery item row. It returns nil for items that
+// were unavailable and not charged.
+func (p *Parser) parseGroceryItem(row *goquery.Selection) *OrderItem {
+	item := &OrderItem{}
+
+	link := row.Find(itemLinkSelector).First()
+	item.ASIN = extractASINFromURL(link.AttrOr("href", ""))
+	title := row.Find("[data-component='itemTitle'], .item-title").First()
+	if title.Length() == 0 {
+		title = link
+	}
+	item.Name = strings.Join(strings.Fields(title.Text()), " ")
+	if item.Name == "" {
+		return nil
+	}
+
+	// Line total as charged; for weighted items this is not a whole multiple of the unit price
+	item.Price = p.marketplace.ParsePrice(row.Find("[data-component='itemPrice'], .item-price").First().Text())
+	rowText := strings.ToLower(row.Text())
+	if item.Price.IsZero() && (strings.Contains(rowText, "unavailable") || strings.Contains(rowText, "not available")) {
+		return nil
+	}
+
+	qtyText := strings.TrimSpace(row.Find("[data-component='quantity'], .item-quantity").First().Text())
+	item.Quantity, item.Unit = parseWeightedQuantity(qtyText)
+
+	if unitPrice := row.Find("[data-component='unitPrice'], .item-unit-price").First().Text(); unitPrice != "" {
+		item.UnitPrice = p.marketplace.ParsePrice(unitPrice)
+	} else if item.Quantity > 0 {
+		item.UnitPrice = item.Price.Div(item.Quantity)
+	}
+	if item.Price.IsZero() {
+		item.Price = item.UnitPrice.Mul(item.Quantity)
+	}
+
+	substitution := strings.Join(strings.Fields(row.Find("[data-component='substitution'], .substitution").First().Text()), " ")
+	if m := substitutionPattern.FindStringSubmatch(substitution); m != nil {
+		item.SubstituteFor = strings.TrimSpace(m[1])
+	}
+
+	return item
+}
+
+// parseWeightedQuantity parses a grocery quantity, which is either a count
+// like "Qty: 2" or a weight like "1.52 lb". The unit is "" for counts.
+func parseWeightedQuantity(text string) (float64, string) {
+	if m := weightPattern.FindStringSubmatch(text); m != nil {
+		if qty, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64); err == nil && qty > 0 {
+			unit := strings.ToLower(m[2])
+			if unit == "lbs" {
+				unit = "lb"
+			}
+			return qty, unit
+		}
+	}
+	return parseQuantity(text), ""
+}
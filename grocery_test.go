@@ -0,0 +1,121 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+)
+
+const testWholeFoodsOrderPage = `<html><body>
+<div data-component="orderHeader"><h1>Whole Foods Market delivery</h1>
+	<span>Order # 113-5555555-1234567</span></div>
+<div data-component="groceryItem">
+	<a href="/dp/B000BANANA"><span class="item-title">Organic Bananas</span></a>
+	<span class="item-quantity">2.31 lb</span>
+	<span class="item-unit-price">$0.69/lb</span>
+	<span class="item-price">$1.59</span>
+</div>
+<div data-component="groceryItem">
+	<a href="/dp/B000MILK01"><span class="item-title">Whole Milk, 1 Gallon</span></a>
+	<span class="item-quantity">Qty: 2</span>
+	<span class="item-price">$8.98</span>
+</div>
+<div data-component="groceryItem">
+	<a href="/dp/B0STRAWB02"><span class="item-title">Organic Strawberries, 1 lb</span></a>
+	<span class="item-quantity">Qty: 1</span>
+	<span class="item-price">$4.99</span>
+	<div class="substitution">Substituted for: Strawberries, 2 lb</div>
+</div>
+<div data-component="groceryItem">
+	<a href="/dp/B0AVOCADO1"><span class="item-title">Hass Avocados, 4 ct</span></a>
+	<span>Item unavailable</span>
+</div>
+<div data-component="chargeSummary">
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Item(s) Subtotal:</span><span class="od-line-item-row-content">$15.56</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Bag fee:</span><span class="od-line-item-row-content">$0.10</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Estimated tax:</span><span class="od-line-item-row-content">$0.51</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Driver tip:</span><span class="od-line-item-row-content">$5.00</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Grand Total:</span><span class="od-line-item-row-content">$21.17</span></div>
+</div>
+</body></html>`
+
+func TestParseOrderDetails_Grocery(t *testing.T) {
+	order, err := NewParser().ParseOrderDetails(strings.NewReader(testWholeFoodsOrderPage))
+	if err != nil {
+		t.Fatalf("ParseOrderDetails failed: %v", err)
+	}
+
+	if order.ID != "113-5555555-1234567" || order.Kind != OrderKindWholeFoods {
+		t.Errorf("Expected Whole Foods order 113-5555555-1234567, got %s (%s)", order.ID, order.Kind)
+	}
+	if order.Tip.Decimal() != "5.00" || order.GetTip() != 5 {
+		t.Errorf("Expected tip 5.00, got %s", order.Tip)
+	}
+	if order.BagFees.Decimal() != "0.10" || order.GetFees() != 0.10 {
+		t.Errorf("Expected bag fees 0.10, got %s (fees %.2f)", order.BagFees, order.GetFees())
+	}
+	if order.Total.Decimal() != "21.17" {
+		t.Errorf("Expected total 21.17, got %s", order.Total)
+	}
+	if err := order.VerifyTotals(); err != nil {
+		t.Errorf("VerifyTotals failed: %v", err)
+	}
+
+	if len(order.Items) != 3 {
+		t.Fatalf("Expected 3 items (unavailable item skipped), got %d", len(order.Items))
+	}
+
+	bananas := order.Items[0]
+	if bananas.Quantity != 2.31 || bananas.Unit != "lb" {
+		t.Errorf("Expected 2.31 lb of bananas, got %v %q", bananas.Quantity, bananas.Unit)
+	}
+	if bananas.UnitPrice.Decimal() != "0.69" || bananas.Price.Decimal() != "1.59" {
+		t.Errorf("Expected $0.69/lb and $1.59, got %s and %s", bananas.UnitPrice, bananas.Price)
+	}
+
+	milk := order.Items[1]
+	if milk.Quantity != 2 || milk.Unit != "" || milk.UnitPrice.Decimal() != "4.49" || milk.Price.Decimal() != "8.98" {
+		t.Errorf("Unexpected milk: qty=%v unit=%q unit price=%s price=%s", milk.Quantity, milk.Unit, milk.UnitPrice, milk.Price)
+	}
+
+	strawberries := order.Items[2]
+	if strawberries.SubstituteFor != "Strawberries, 2 lb" {
+		t.Errorf("Expected substitute for %q, got %q", "Strawberries, 2 lb", strawberries.SubstituteFor)
+	}
+	if strawberries.Quantity != 1 || strawberries.Unit != "" {
+		t.Errorf("Expected substitution quantity 1, got %v %q", strawberries.Quantity, strawberries.Unit)
+	}
+}
+
+func TestParseWeightedQuantity(t *testing.T) {
+	tests := []struct {
+		text string
+		qty  float64
+		unit string
+	}{
+		{"1.52 lb", 1.52, "lb"},
+		{"Weight: 0,8 kg", 0.8, "kg"},
+		{"2 lbs", 2, "lb"},
+		{"Qty: 3", 3, ""},
+		{"", 1, ""},
+	}
+
+	for _, tc := range tests {
+		qty, unit := parseWeightedQuantity(tc.text)
+		if qty != tc.qty || unit != tc.unit {
+			t.Errorf("parseWeightedQuantity(%q) = %v %q, want %v %q", tc.text, qty, unit, tc.qty, tc.unit)
+		}
+	}
+}
+
+func TestGroceryOrderKind(t *testing.T) {
+	tests := map[string]OrderKind{
+		"Whole Foods Market delivery": OrderKindWholeFoods,
+		"Amazon Fresh delivery":       OrderKindFresh,
+		"In-store purchase":           OrderKindInStore,
+	}
+	for text, expected := range tests {
+		if kind := groceryOrderKind(text); kind != expected {
+			t.Errorf("groceryOrderKind(%q) = %s, want %s", text, kind, expected)
+		}
+	}
+}
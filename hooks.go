@@ -0,0 +1,114 @@
+package amazon
+
+import "time"
+
+// Hooks receives progress events from a Client, e.g. to show "fetched page 3
+// of year 2024, 27/112 details". Any field may be nil. Hooks are called
+// synchronously, and from several goroutines at once when WithConcurrency is
+// above 1, so they must be quick and safe for concurrent use.
+type Hooks struct {
+	OnPageFetched    func(PageFetchedEvent)   // An order list page was read
+	OnSummaryParsed  func(SummaryParsedEvent) // An order was found on an order list page
+	OnDetailFetched  func(DetailEvent)        // An order's details or transactions were fetched
+	OnDetailFailed   func(DetailEvent)        // An order's details or transactions could not be fetched
+	OnRetryScheduled func(RetryEvent)         // A failed request will be retried
+	OnRateLimitWait  func(RateLimitWaitEvent) // A request was held back by the rate limiter
+	OnCookiesSaved   func(CookiesSavedEvent)  // Cookies were written to the cookie file
+}
+
+// PageFetchedEvent reports an order list page that was read
+type PageFetchedEvent struct {
+	URL         string
+	Filter      string    // Time filter, e.g. "year-2024" or "months-3"
+	Year        int       // Year of a year filter, 0 otherwise
+	Kind        OrderKind // Physical or digital order list
+	Page        int       // Page number within the list, starting at 1
+	Orders      int       // Orders on the page not seen on earlier pages
+	TotalOrders int       // Orders the list reports; 0 if not shown
+}
+
+// SummaryParsedEvent reports an order found on an order list page
+type SummaryParsedEvent struct {
+	Summary *OrderSummary
+	Filter  string
+	Kind    OrderKind
+	Page    int
+}
+
+// DetailEvent reports an order's details or transactions being fetched
+type DetailEvent struct {
+	PageType PageType // PageTypeOrderDetails or PageTypeTransactions
+	OrderID  string
+	Err      error // Why the fetch failed; nil for OnDetailFetched
+	Done     int   // Orders of the batch processed so far, this one included
+	Total    int   // Orders in the batch; 0 when not known in advance, as with OrderIterator
+}
+
+// RetryEvent reports a failed request that will be retried
+type RetryEvent struct {
+	URL        string
+	Attempt    int           // The attempt that failed, starting at 1
+	Delay      time.Duration // Wait before the next attempt
+	StatusCode int           // 0 if the request failed without a response
+	Err        error         // Transport error, if any
+}
+
+// RateLimitWaitEvent reports a request that waited for the rate limiter
+// before it was sent
+type RateLimitWaitEvent struct {
+	URL      string
+	PageType PageType
+	Wait     time.Duration
+}
+
+// CookiesSavedEvent reports cookies written to the cookie file
+type CookiesSavedEvent struct {
+	Path    string
+	Cookies int
+}
+
+func (h *Hooks) pageFetched(e PageFetchedEvent) {
+	if h != nil && h.OnPageFetched != nil {
+		h.OnPageFetched(e)
+	}
+}
+
+func (h *Hooks) summaryParsed(e SummaryParsedEvent) {
+	if h != nil && h.OnSummaryParsed != nil {
+		h.OnSummaryParsed(e)
+	}
+}
+
+// detail reports a fetched or failed order depending on e.Err
+func (h *Hooks) detail(e DetailEvent) {
+	if h == nil {
+		return
+	}
+	if e.Err != nil {
+		if h.OnDetailFailed != nil {
+			h.OnDetailFailed(e)
+		}
+		return
+	}
+	if h.OnDetailFetched != nil {
+		h.OnDetailFetched(e)
+	}
+}
+
+func (h *Hooks) retryScheduled(e RetryEvent) {
+	if h != nil && h.OnRetryScheduled != nil {
+		h.OnRetryScheduled(e)
+	}
+}
+
+func (h *Hooks) rateLimitWait(e RateLimitWaitEvent) {
+	if h != nil && h.OnRateLimitWait != nil {
+		h.OnRateLimitWait(e)
+	}
+}
+
+func (h *Hooks) cookiesSaved(e CookiesSavedEvent) {
+	if h != nil && h.OnCookiesSaved != nil {
+		h.OnCookiesSaved(e)
+	}
+}
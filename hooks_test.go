@@ -0,0 +1,127 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHooks_FetchOrders(t *testing.T) {
+	var mu sync.Mutex
+	var pages []PageFetchedEvent
+	var summaries, fetched int
+	var failed []DetailEvent
+	var last DetailEvent
+	hooks := &Hooks{
+		OnPageFetched: func(e PageFetchedEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			pages = append(pages, e)
+		},
+		OnSummaryParsed: func(e SummaryParsedEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			summaries++
+		},
+		OnDetailFetched: func(e DetailEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			fetched++
+			if e.Done > last.Done {
+				last = e
+			}
+		},
+		OnDetailFailed: func(e DetailEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, e)
+			if e.Done > last.Done {
+				last = e
+			}
+		},
+	}
+
+	client, _ := newPagedFakeAmazon(t, WithHooks(hooks), WithConcurrency(3))
+	_, err := client.FetchOrders(context.Background(), FetchOptions{Year: 2025, IncludeDetails: true})
+	var orderErrs OrderErrors
+	if !errors.As(err, &orderErrs) {
+		t.Fatalf("Expected OrderErrors, got %v", err)
+	}
+
+	if len(pages) != 3 {
+		t.Fatalf("Expected 3 page events, got %d", len(pages))
+	}
+	for i, page := range pages {
+		if page.Page != i+1 || page.Year != 2025 || page.Kind != OrderKindPhysical || page.TotalOrders != 7 {
+			t.Errorf("Unexpected page event %+v", page)
+		}
+	}
+	if pages[2].Orders != 1 || summaries != 7 {
+		t.Errorf("Expected 7 summaries with 1 on the last page, got %d and %d", summaries, pages[2].Orders)
+	}
+	if fetched != 6 || len(failed) != 1 || failed[0].OrderID != "114-0000000-0000002" || failed[0].Err == nil {
+		t.Errorf("Expected 6 fetched and 1 failed detail, got %d and %+v", fetched, failed)
+	}
+	if last.Done != 7 || last.Total != 7 || last.PageType != PageTypeOrderDetails {
+		t.Errorf("Unexpected last detail event %+v", last)
+	}
+}
+
+func TestHooks_Requests(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		first := attempts == 1
+		mu.Unlock()
+		if first {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session-id", Value: "123"})
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	var retries []RetryEvent
+	var waits []RateLimitWaitEvent
+	var saved []CookiesSavedEvent
+	cookieFile := filepath.Join(t.TempDir(), "cookies.json")
+	client, err := NewClient(
+		WithCookieFile(cookieFile),
+		WithBaseURL(server.URL),
+		WithRateLimit(20*time.Millisecond),
+		WithRetryPolicy(&ExponentialBackoff{MaxRetries: 1, BaseDelay: time.Millisecond}),
+		WithHooks(&Hooks{
+			OnRetryScheduled: func(e RetryEvent) { retries = append(retries, e) },
+			OnRateLimitWait:  func(e RateLimitWaitEvent) { waits = append(waits, e) },
+			OnCookiesSaved:   func(e CookiesSavedEvent) { saved = append(saved, e) },
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	body, err := client.pageSource.FetchPage(context.Background(), client.pageURL(ordersPath))
+	if err != nil {
+		t.Fatalf("FetchPage failed: %v", err)
+	}
+	body.Close()
+
+	if len(retries) != 1 || retries[0].Attempt != 1 || retries[0].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected one retry after a 503, got %+v", retries)
+	}
+	// The first attempt is free; the retry waits for the next token
+	if len(waits) != 1 || waits[0].Wait <= 0 || waits[0].PageType != PageTypeOrderList {
+		t.Errorf("Expected one rate limit wait, got %+v", waits)
+	}
+	if len(saved) != 1 || saved[0].Path != cookieFile || saved[0].Cookies != 1 {
+		t.Errorf("Expected cookies saved to %s, got %+v", cookieFile, saved)
+	}
+}
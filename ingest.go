@@ -0,0 +1,220 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultMaxIngestBodySize = 20 << 20 // 20 MB, order pages are large
+
+// PageType identifies which Amazon page a snapshot was taken from
+type PageType string
+
+const (
+	PageTypeOrderList     PageType = "order-list"
+	PageTypeOrderDetails  PageType = "order-details"
+	PageTypeTransactions  PageType = "transactions"
+	PageTypeTracking      PageType = "tracking"
+	PageTypeReturns       PageType = "returns"
+	PageTypeSubscriptions PageType = "subscriptions"
+	PageTypeInvoice       PageType = "invoice"
+)
+
+// IngestRequest is the JSON body POSTed to the ingest handler.
+// HTML must be the DOM after client-side decryption has run in the browser.
+type IngestRequest struct {
+	Type PageType `json:"type"`
+	URL  string   `json:"url"`
+	HTML string   `json:"html"`
+}
+
+// IngestResponse is the JSON body returned by the ingest handler
+type IngestResponse struct {
+	Type  PageType `json:"type,omitempty"`
+	Count int      `json:"count"`
+	Error string   `json:"error,omitempty"`
+}
+
+// IngestSink receives the results parsed from ingested page snapshots
+type IngestSink interface {
+	HandleOrderSummaries(ctx context.Context, pageURL string, summaries []*OrderSummary) error
+	HandleOrder(ctx context.Context, pageURL string, order *Order) error
+	HandleTransactions(ctx context.Context, pageURL string, transactions []*Transaction) error
+}
+
+// IngestHandler is an http.Handler that accepts already-decrypted page
+// snapshots (e.g. from a browser extension), parses them and passes the
+// results to an IngestSink
+type IngestHandler struct {
+	sink        IngestSink
+	parser      *Parser
+	logger      *slog.Logger
+	maxBodySize int64
+}
+
+// NewIngestHandler creates an ingest handler that delivers results to sink
+func NewIngestHandler(sink IngestSink, logger *slog.Logger) *IngestHandler {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return &IngestHandler{
+		sink:        sink,
+		parser:      NewParser(),
+		logger:      logger.With("component", "ingest"),
+		maxBodySize: defaultMaxIngestBodySize,
+	}
+}
+
+// parserFor returns a parser for the marketplace a page was captured from,
+// falling back to amazon.com if the URL isn't recognized
+func (h *IngestHandler) parserFor(pageURL string) *Parser {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return h.parser
+	}
+	if m := marketplaceForHost(u.Hostname()); m != nil {
+		return NewMarketplaceParser(m)
+	}
+	return h.parser
+}
+
+// ServeHTTP implements http.Handler. Malformed requests get 400, bodies over
+// the size limit 413, pages that can't be parsed 422 and sink failures 500.
+func (h *IngestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.writeJSON(w, http.StatusMethodNotAllowed, IngestResponse{Error: "method not allowed"})
+		return
+	}
+
+	var req IngestRequest
+	body := http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeJSON(w, http.StatusRequestEntityTooLarge, IngestResponse{Error: fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)})
+			return
+		}
+		h.writeJSON(w, http.StatusBadRequest, IngestResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+
+	if req.HTML == "" {
+		h.writeJSON(w, http.StatusBadRequest, IngestResponse{Type: req.Type, Error: "html is required"})
+		return
+	}
+
+	count, err := h.Ingest(r.Context(), req)
+	if err != nil {
+		h.logger.Warn("failed to ingest page",
+			"type", req.Type,
+			"url", req.URL,
+			"error", err,
+		)
+		// A failing sink is the server's problem, not a bad page
+		status := http.StatusUnprocessableEntity
+		var sinkErr *sinkError
+		if errors.As(err, &sinkErr) {
+			status = http.StatusInternalServerError
+		}
+		h.writeJSON(w, status, IngestResponse{Type: req.Type, Error: err.Error()})
+		return
+	}
+
+	h.logger.Debug("ingested page",
+		"type", req.Type,
+		"url", req.URL,
+		"count", count,
+	)
+
+	h.writeJSON(w, http.StatusOK, IngestResponse{Type: req.Type, Count: count})
+}
+
+// Ingest parses a single page snapshot and passes the results to the sink.
+// It returns the number of orders or transactions parsed.
+func (h *IngestHandler) Ingest(ctx context.Context, req IngestRequest) (int, error) {
+	html := strings.NewReader(req.HTML)
+	parser := h.parserFor(req.URL)
+
+	switch req.Type {
+	case PageTypeOrderList:
+		summaries, err := parser.ParseOrderList(html)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse order list: %w", err)
+		}
+		if err := h.sink.HandleOrderSummaries(ctx, req.URL, summaries); err != nil {
+			return 0, &sinkError{err: err}
+		}
+		return len(summaries), nil
+
+	case PageTypeOrderDetails:
+		order, err := parser.ParseOrderDetails(html)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse order details: %w", err)
+		}
+		// Ensure order ID is set
+		if order.ID == "" {
+			order.ID = queryParam(req.URL, "orderID")
+		}
+		if err := h.sink.HandleOrder(ctx, req.URL, order); err != nil {
+			return 0, &sinkError{err: err}
+		}
+		return 1, nil
+
+	case PageTypeTransactions:
+		transactions, err := parser.ParseTransactions(html)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse transactions: %w", err)
+		}
+		// Ensure order ID is set on all transactions
+		if orderID := queryParam(req.URL, "transactionTag"); orderID != "" {
+			for _, tx := range transactions {
+				if tx.OrderID == "" {
+					tx.OrderID = orderID
+				}
+			}
+		}
+		if err := h.sink.HandleTransactions(ctx, req.URL, transactions); err != nil {
+			return 0, &sinkError{err: err}
+		}
+		return len(transactions), nil
+
+	default:
+		return 0, fmt.Errorf("unknown page type: %q", req.Type)
+	}
+}
+
+// sinkError wraps an error returned by the IngestSink, as opposed to a page
+// that could not be parsed
+type sinkError struct {
+	err error
+}
+
+func (e *sinkError) Error() string { return "sink failed: " + e.err.Error() }
+func (e *sinkError) Unwrap() error { return e.err }
+
+// writeJSON writes a JSON response with the given status code
+func (h *IngestHandler) writeJSON(w http.ResponseWriter, status int, resp IngestResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Warn("failed to write response", "error", err)
+	}
+}
+
+// queryParam returns a query parameter from a URL, or "" if it cannot be parsed
+func queryParam(rawURL, name string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get(name)
+}
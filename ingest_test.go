@@ -0,0 +1,127 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testIngestSink struct {
+	summaries    []*OrderSummary
+	orders       []*Order
+	transactions []*Transaction
+	err          error
+}
+
+func (s *testIngestSink) HandleOrderSummaries(ctx context.Context, pageURL string, summaries []*OrderSummary) error {
+	s.summaries = append(s.summaries, summaries...)
+	return s.err
+}
+
+func (s *testIngestSink) HandleOrder(ctx context.Context, pageURL string, order *Order) error {
+	s.orders = append(s.orders, order)
+	return s.err
+}
+
+func (s *testIngestSink) HandleTransactions(ctx context.Context, pageURL string, transactions []*Transaction) error {
+	s.transactions = append(s.transactions, transactions...)
+	return s.err
+}
+
+func postIngest(t *testing.T, h http.Handler, req IngestRequest) (*httptest.ResponseRecorder, IngestResponse) {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader(string(body))))
+
+	var resp IngestResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	return rec, resp
+}
+
+func TestIngestHandler_OrderList(t *testing.T) {
+	sink := &testIngestSink{}
+	h := NewIngestHandler(sink, nil)
+
+	html := `<div class="order-card">
+		<ul><li class="order-header__header-list-item">Order placed November 26, 2025</li>
+		<li class="order-header__header-list-item">Total $44.91</li></ul>
+		<a href="/your-orders/order-details?orderID=114-9733092-9360267">View order details</a>
+	</div>`
+
+	rec, resp := postIngest(t, h, IngestRequest{Type: PageTypeOrderList, URL: MarketplaceUS.BaseURL() + ordersPath, HTML: html})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, resp.Error)
+	}
+	if resp.Count != 1 || len(sink.summaries) != 1 {
+		t.Fatalf("Expected 1 summary, got count=%d sink=%d", resp.Count, len(sink.summaries))
+	}
+	if sink.summaries[0].ID != "114-9733092-9360267" {
+		t.Errorf("Unexpected order ID: %s", sink.summaries[0].ID)
+	}
+	if sink.summaries[0].Total.Decimal() != "44.91" {
+		t.Errorf("Expected total 44.91, got %s", sink.summaries[0].Total)
+	}
+}
+
+func TestIngestHandler_OrderDetailsUsesURLForID(t *testing.T) {
+	sink := &testIngestSink{}
+	h := NewIngestHandler(sink, nil)
+
+	rec, _ := postIngest(t, h, IngestRequest{
+		Type: PageTypeOrderDetails,
+		URL:  MarketplaceUS.BaseURL() + orderDetailsPath + "?orderID=113-7382612-3141857",
+		HTML: "<html><body>No order number here</body></html>",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if len(sink.orders) != 1 || sink.orders[0].ID != "113-7382612-3141857" {
+		t.Errorf("Expected order ID from URL, got %+v", sink.orders)
+	}
+}
+
+func TestIngestHandler_Errors(t *testing.T) {
+	sink := &testIngestSink{}
+	h := NewIngestHandler(sink, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ingest", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader("not json")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid JSON, got %d", rec.Code)
+	}
+
+	h.maxBodySize = 64
+	rec, _ = postIngest(t, h, IngestRequest{Type: PageTypeOrderList, HTML: "<html><body>" + strings.Repeat("x", 100) + "</body></html>"})
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for an oversized body, got %d", rec.Code)
+	}
+	h.maxBodySize = defaultMaxIngestBodySize
+
+	rec, _ = postIngest(t, h, IngestRequest{Type: "unknown", HTML: "<html></html>"})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for unknown page type, got %d", rec.Code)
+	}
+
+	sink.err = errors.New("store unavailable")
+	rec, resp := postIngest(t, h, IngestRequest{Type: PageTypeTransactions, HTML: "<html></html>"})
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(resp.Error, "store unavailable") {
+		t.Errorf("Expected 500 with the sink error, got %d %q", rec.Code, resp.Error)
+	}
+}
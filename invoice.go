@@ -0,0 +1,315 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// invoicePath is the printable invoice for an order
+const invoicePath = "/gp/css/summary/print.html"
+
+// Invoice is the printable invoice for an order: what was bought from which
+// seller, where it was shipped and billed, and how it was paid
+type Invoice struct {
+	OrderID         string         `json:"order_id"`
+	Date            time.Time      `json:"date"`
+	Items           []*OrderItem   `json:"items"` // With Seller and Condition as printed
+	ShippingAddress *Address       `json:"shipping_address,omitempty"`
+	BillingAddress  *Address       `json:"billing_address,omitempty"`
+	PaymentMethod   string         `json:"payment_method,omitempty"` // As printed, e.g. "Visa | Last digits: 1234"
+	Payments        []*Transaction `json:"payments,omitempty"`       // Card charges listed on the invoice
+	Adjustments     []*Adjustment  `json:"adjustments,omitempty"`    // Charge summary, in page order
+	Total           Money          `json:"total"`
+}
+
+// Address is a postal address as printed on an invoice
+type Address struct {
+	Name  string   `json:"name"`
+	Lines []string `json:"lines"` // Street, city/state/postal code and country lines
+}
+
+// String returns the address on one line
+func (a *Address) String() string {
+	return strings.Join(append([]string{a.Name}, a.Lines...), ", ")
+}
+
+var (
+	// "2 of: Example Product"
+	invoiceQtyPattern = regexp.MustCompile(`^(\d+)\s+of:`)
+	// "Visa ending in 1234: November 21, 2025:"
+	cardEndingPattern = regexp.MustCompile(`(?i)ending in\s*(\d{4})`)
+	// Labels that follow each other in an invoice item cell
+	invoiceItemLabels = []string{"Sold by:", "Supplied by:", "Condition:", "Business Price"}
+)
+
+// isInvoicePage reports whether a page is a printable order invoice
+func isInvoicePage(doc *goquery.Document) bool {
+	text := doc.Find("title, body").Text()
+	return strings.Contains(text, "Final Details for Order") || strings.Contains(text, "Invoice for Order")
+}
+
+// ParseInvoice parses an order's printable invoice page
+func (p *Parser) ParseInvoice(r io.Reader) (*Invoice, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeInvoice, Err: err}
+	}
+
+	invoice := &Invoice{
+		OrderID: extractOrderIDFromText(doc.Find("body").Text()),
+		Date:    p.orderPlacedDate(doc),
+	}
+	// The charge summary is read into a scratch order to reuse its classification
+	charges := &Order{}
+
+	doc.Find("tr").Each(func(i int, row *goquery.Selection) {
+		// Layout tables nest; only read the innermost rows
+		if row.Find("tr").Length() > 0 {
+			return
+		}
+		cells := row.ChildrenFiltered("td")
+		if cells.Length() < 2 {
+			return
+		}
+		first := strings.Join(strings.Fields(cells.First().Text()), " ")
+		valueText := strings.TrimSpace(cells.Last().Text())
+
+		switch {
+		case invoiceQtyPattern.MatchString(first):
+			invoice.Items = append(invoice.Items, p.parseInvoiceItem(cells.First(), first, valueText))
+		case cardEndingPattern.MatchString(first):
+			if tx := p.parseInvoicePayment(first, valueText); tx != nil {
+				tx.OrderID = invoice.OrderID
+				invoice.Payments = append(invoice.Payments, tx)
+			}
+		case strings.HasSuffix(first, ":") && p.marketplace.hasCurrency(valueText):
+			p.addAdjustment(charges, first, valueText)
+		}
+	})
+	invoice.Adjustments = charges.Adjustments
+	invoice.Total = charges.Total
+
+	invoice.ShippingAddress = invoiceAddress(doc, "Shipping Address")
+	invoice.BillingAddress = invoiceAddress(doc, "Billing address")
+	invoice.PaymentMethod = invoicePaymentMethod(doc)
+
+	if invoice.OrderID == "" && len(invoice.Items) == 0 && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeInvoice, Err: ErrEncryptedContent}
+	}
+
+	return invoice, nil
+}
+
+// parseInvoiceItem reads an item cell like "1 of: Example Product Sold by:
+// Example Seller (seller profile) Condition: New" and its price cell, which
+// holds the price of one unit
+func (p *Parser) parseInvoiceItem(cell *goquery.Selection, text, priceText string) *OrderItem {
+	item := &OrderItem{Quantity: 1}
+	if m := invoiceQtyPattern.FindStringSubmatch(text); m != nil {
+		item.Quantity, _ = strconv.ParseFloat(m[1], 64)
+	}
+
+	if link := cell.Find(itemLinkSelector).First(); link.Length() > 0 {
+		item.ASIN = extractASINFromURL(link.AttrOr("href", ""))
+	}
+	if title := cell.Find("i").First(); title.Length() > 0 {
+		item.Name = strings.Join(strings.Fields(title.Text()), " ")
+	} else {
+		item.Name = invoiceField(text, "of:")
+	}
+	item.Seller = strings.TrimSuffix(invoiceField(text, "Sold by:"), " (seller profile)")
+	item.Condition = invoiceField(text, "Condition:")
+
+	item.UnitPrice, _ = p.marketplace.findPrice(priceText)
+	item.Price = item.UnitPrice.Mul(item.Quantity)
+	return item
+}
+
+// invoiceField returns the text after label in an invoice item cell, up to
+// the next known label
+func invoiceField(text, label string) string {
+	idx := strings.Index(text, label)
+	if idx < 0 {
+		return ""
+	}
+	value := text[idx+len(label):]
+	for _, next := range invoiceItemLabels {
+		if end := strings.Index(value, next); end >= 0 {
+			value = value[:end]
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseInvoicePayment reads a row like "Visa ending in 1234: November 21,
+// 2025:" with the amount charged in the last cell
+func (p *Parser) parseInvoicePayment(text, amountText string) *Transaction {
+	parts := strings.Split(strings.TrimSuffix(text, ":"), ":")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	tx := &Transaction{
+		PaymentMethod: strings.TrimSpace(parts[0]),
+		Status:        "Completed",
+	}
+	tx.CardType, _ = parsePaymentMethod(tx.PaymentMethod)
+	tx.CardType = strings.TrimSpace(strings.Split(tx.CardType, " ending in")[0])
+	if m := cardEndingPattern.FindStringSubmatch(text); m != nil {
+		tx.LastFour = m[1]
+	}
+	if date, err := p.marketplace.ParseDate(strings.TrimSpace(parts[len(parts)-1])); err == nil {
+		tx.Date = date
+	}
+	tx.Amount, _ = p.marketplace.findPrice(amountText)
+	if isRefundRow(strings.TrimSpace(amountText), text) {
+		tx.Amount.Units = -abs(tx.Amount.Units)
+		tx.Status = "Refunded"
+	}
+	return tx
+}
+
+// invoiceAddress reads the address printed after a bold label such as
+// "Shipping Address:", or returns nil if the invoice doesn't have one
+func invoiceAddress(doc *goquery.Document, label string) *Address {
+	var address *Address
+	doc.Find("b").EachWithBreak(func(i int, b *goquery.Selection) bool {
+		if !strings.Contains(strings.ToLower(b.Text()), strings.ToLower(label)) {
+			return true
+		}
+		div := b.NextAllFiltered(".displayAddressDiv, ul.displayAddressUL").First()
+		if div.Length() == 0 {
+			div = b.Parent().Find(".displayAddressDiv, ul.displayAddressUL").First()
+		}
+		if div.Length() == 0 {
+			return true
+		}
+
+		address = &Address{}
+		div.Find("li").Each(func(j int, li *goquery.Selection) {
+			line := strings.Join(strings.Fields(li.Text()), " ")
+			switch {
+			case line == "":
+			case li.HasClass("displayAddressFullName"):
+				address.Name = line
+			default:
+				address.Lines = append(address.Lines, line)
+			}
+		})
+		return false
+	})
+	return address
+}
+
+// invoicePaymentMethod reads the text following "Payment Method:", which sits
+// in the same cell as the billing address
+func invoicePaymentMethod(doc *goquery.Document) string {
+	var method string
+	doc.Find("b:contains('Payment Method')").EachWithBreak(func(i int, b *goquery.Selection) bool {
+		text := strings.Join(strings.Fields(b.Parent().Text()), " ")
+		idx := strings.Index(text, "Payment Method:")
+		if idx < 0 {
+			return true
+		}
+		text = text[idx+len("Payment Method:"):]
+		if end := strings.Index(strings.ToLower(text), "billing address"); end >= 0 {
+			text = text[:end]
+		}
+		method = strings.TrimSpace(text)
+		return method == ""
+	})
+	return method
+}
+
+// ApplyInvoice attaches an invoice to the order and copies each item's seller
+// and condition onto the matching order item, by ASIN or else by name. An
+// order without parsed items takes the invoice's items.
+func (o *Order) ApplyInvoice(invoice *Invoice) {
+	o.Invoice = invoice
+	if o.ID == "" {
+		o.ID = invoice.OrderID
+	}
+	if len(o.Items) == 0 {
+		o.Items = invoice.Items
+		return
+	}
+
+	for _, item := range o.Items {
+		match := invoiceItemFor(invoice, item)
+		if match == nil {
+			continue
+		}
+		if item.Seller == "" {
+			item.Seller = match.Seller
+		}
+		if item.Condition == "" {
+			item.Condition = match.Condition
+		}
+	}
+}
+
+// invoiceItemFor finds the invoice line for an order item. Order pages
+// shorten long titles, so a name matches if either is a prefix of the other.
+func invoiceItemFor(invoice *Invoice, item *OrderItem) *OrderItem {
+	for _, line := range invoice.Items {
+		if item.ASIN != "" && line.ASIN == item.ASIN {
+			return line
+		}
+	}
+	name := strings.ToLower(item.Name)
+	if name == "" {
+		return nil
+	}
+	for _, line := range invoice.Items {
+		lineName := strings.ToLower(line.Name)
+		if lineName != "" && (strings.HasPrefix(lineName, name) || strings.HasPrefix(name, lineName)) {
+			return line
+		}
+	}
+	return nil
+}
+
+// FetchInvoice fetches an order's printable invoice and applies it to the
+// order, filling in item sellers and conditions
+func (c *Client) FetchInvoice(ctx context.Context, order *Order) (*Invoice, error) {
+	if order == nil || order.ID == "" {
+		return nil, fmt.Errorf("order has no ID")
+	}
+
+	u, _ := url.Parse(c.pageURL(invoicePath))
+	q := u.Query()
+	q.Set("orderID", order.ID)
+	u.RawQuery = q.Encode()
+
+	body, err := c.pageSource.FetchPage(ctx, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+	defer body.Close()
+
+	invoice, err := c.newParser().ParseInvoice(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse invoice: %w", withPageURL(err, u.String()))
+	}
+	if invoice.OrderID == "" {
+		invoice.OrderID = order.ID
+		for _, tx := range invoice.Payments {
+			tx.OrderID = order.ID
+		}
+	}
+
+	order.ApplyInvoice(invoice)
+	return invoice, nil
+}
@@ -0,0 +1,189 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testInvoicePage = `<html><head><title>Amazon.com - Order 113-1234567-7654321</title></head><body>
+<center><b class="h1">Final Details for Order #113-1234567-7654321</b></center>
+<table width="90%"><tr><td>
+	<table>
+		<tr><td><b>Order Placed:</b> November 20, 2025</td></tr>
+		<tr><td><b>Amazon.com order number:</b> 113-1234567-7654321</td></tr>
+		<tr><td><b>Order Total: $44.91</b></td></tr>
+	</table>
+	<table>
+		<tr><td><b>Shipped on November 21, 2025</b></td></tr>
+		<tr><th>Items Ordered</th><th>Price</th></tr>
+		<tr><td>2 of: <i>USB-C Cable, 6 ft, Braided</i><br>
+			Sold by: Example Electronics LLC (seller profile)<br>
+			Condition: New</td><td>$9.99</td></tr>
+		<tr><td>1 of: <i>The Example Handbook, 2nd Edition</i><br>
+			Sold by: Amazon.com Services, Inc<br>
+			Condition: Used - Very Good</td><td>$21.86</td></tr>
+		<tr><td><b>Shipping Address:</b><br>
+			<div class="displayAddressDiv"><ul class="displayAddressUL">
+				<li class="displayAddressFullName">Jane Doe</li>
+				<li class="displayAddressAddressLine1">123 Main St</li>
+				<li class="displayAddressCityStateOrRegionPostalCode">Springfield, IL 62701</li>
+				<li class="displayAddressCountryName">United States</li>
+			</ul></div></td><td></td></tr>
+	</table>
+	<table>
+		<tr><td><b>Payment Method:</b><br> Visa <nobr>| Last digits: 1234</nobr><br><br>
+			<b>Billing address</b>
+			<div class="displayAddressDiv"><ul class="displayAddressUL">
+				<li class="displayAddressFullName">Jane Doe</li>
+				<li class="displayAddressAddressLine1">PO Box 42</li>
+				<li class="displayAddressCityStateOrRegionPostalCode">Springfield, IL 62705</li>
+			</ul></div></td>
+		<td><table>
+			<tr><td>Item(s) Subtotal:</td><td>$41.84</td></tr>
+			<tr><td>Shipping &amp; Handling:</td><td>$0.00</td></tr>
+			<tr><td>Total before tax:</td><td>$41.84</td></tr>
+			<tr><td>Estimated tax to be collected:</td><td>$3.07</td></tr>
+			<tr><td><b>Grand Total:</b></td><td><b>$44.91</b></td></tr>
+		</table></td></tr>
+	</table>
+	<table>
+		<tr><td><b>Credit Card transactions</b></td></tr>
+		<tr><td>Visa ending in 1234: November 21, 2025:</td><td>$44.91</td></tr>
+	</table>
+</td></tr></table>
+</body></html>`
+
+func TestParseInvoice(t *testing.T) {
+	invoice, err := NewParser().ParseInvoice(strings.NewReader(testInvoicePage))
+	if err != nil {
+		t.Fatalf("ParseInvoice failed: %v", err)
+	}
+
+	if invoice.OrderID != "113-1234567-7654321" || invoice.Date.Format("2006-01-02") != "2025-11-20" {
+		t.Errorf("Unexpected order: %s %v", invoice.OrderID, invoice.Date)
+	}
+	if invoice.Total.Decimal() != "44.91" || len(invoice.Adjustments) != 5 {
+		t.Errorf("Expected total 44.91 from 5 charge rows, got %s from %d", invoice.Total, len(invoice.Adjustments))
+	}
+
+	if len(invoice.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(invoice.Items))
+	}
+	cable := invoice.Items[0]
+	if cable.Name != "USB-C Cable, 6 ft, Braided" || cable.Quantity != 2 || cable.UnitPrice.Decimal() != "9.99" || cable.Price.Decimal() != "19.98" {
+		t.Errorf("Unexpected cable: %q qty=%v unit=%s price=%s", cable.Name, cable.Quantity, cable.UnitPrice, cable.Price)
+	}
+	if cable.Seller != "Example Electronics LLC" || cable.Condition != "New" {
+		t.Errorf("Unexpected seller/condition: %q %q", cable.Seller, cable.Condition)
+	}
+	book := invoice.Items[1]
+	if book.Seller != "Amazon.com Services, Inc" || book.Condition != "Used - Very Good" {
+		t.Errorf("Unexpected seller/condition: %q %q", book.Seller, book.Condition)
+	}
+
+	if invoice.ShippingAddress == nil || invoice.ShippingAddress.String() != "Jane Doe, 123 Main St, Springfield, IL 62701, United States" {
+		t.Errorf("Unexpected shipping address: %v", invoice.ShippingAddress)
+	}
+	if invoice.BillingAddress == nil || invoice.BillingAddress.String() != "Jane Doe, PO Box 42, Springfield, IL 62705" {
+		t.Errorf("Unexpected billing address: %v", invoice.BillingAddress)
+	}
+	if invoice.PaymentMethod != "Visa | Last digits: 1234" {
+		t.Errorf("Unexpected payment method: %q", invoice.PaymentMethod)
+	}
+
+	if len(invoice.Payments) != 1 {
+		t.Fatalf("Expected 1 payment, got %d", len(invoice.Payments))
+	}
+	tx := invoice.Payments[0]
+	if tx.OrderID != invoice.OrderID || tx.CardType != "Visa" || tx.LastFour != "1234" || tx.Amount.Decimal() != "44.91" || tx.Date.Format("2006-01-02") != "2025-11-21" {
+		t.Errorf("Unexpected payment: %+v", tx)
+	}
+}
+
+func TestClassifyPage_Invoice(t *testing.T) {
+	pageType, err := NewParser().ClassifyPage(strings.NewReader(testInvoicePage))
+	if err != nil {
+		t.Fatalf("ClassifyPage failed: %v", err)
+	}
+	if pageType != PageTypeInvoice {
+		t.Errorf("Expected %q, got %q", PageTypeInvoice, pageType)
+	}
+}
+
+func TestOrder_ApplyInvoice(t *testing.T) {
+	invoice, err := NewParser().ParseInvoice(strings.NewReader(testInvoicePage))
+	if err != nil {
+		t.Fatalf("ParseInvoice failed: %v", err)
+	}
+
+	// Order pages shorten titles
+	order := &Order{
+		ID: "113-1234567-7654321",
+		Items: []*OrderItem{
+			{ASIN: "B0CABLE001", Name: "USB-C Cable, 6 ft"},
+			{ASIN: "B0BOOK0001", Name: "The Example Handbook, 2nd Edition"},
+			{ASIN: "B0OTHER001", Name: "Something Else"},
+		},
+	}
+	order.ApplyInvoice(invoice)
+
+	if order.Invoice != invoice {
+		t.Error("Expected invoice to be attached to the order")
+	}
+	if order.Items[0].Seller != "Example Electronics LLC" || order.Items[0].Condition != "New" {
+		t.Errorf("Unexpected first item: %q %q", order.Items[0].Seller, order.Items[0].Condition)
+	}
+	if order.Items[1].Condition != "Used - Very Good" {
+		t.Errorf("Expected used condition, got %q", order.Items[1].Condition)
+	}
+	if order.Items[2].Seller != "" {
+		t.Errorf("Expected unmatched item to stay empty, got %q", order.Items[2].Seller)
+	}
+}
+
+func TestClient_FetchInvoice(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(invoicePath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("orderID") {
+		case "113-1234567-7654321":
+			w.Write([]byte(testInvoicePage))
+		case "113-0000000-0000000":
+			// An invoice that doesn't print its order number
+			w.Write([]byte(strings.ReplaceAll(testInvoicePage, "113-1234567-7654321", "")))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	order := &Order{ID: "113-1234567-7654321"}
+	if _, err := client.FetchInvoice(context.Background(), order); err != nil {
+		t.Fatalf("FetchInvoice failed: %v", err)
+	}
+	if order.Invoice == nil || len(order.Items) != 2 || order.Items[0].Seller != "Example Electronics LLC" {
+		t.Errorf("Expected order without items to take the invoice's items, got %d items", len(order.Items))
+	}
+
+	invoice, err := client.FetchInvoice(context.Background(), &Order{ID: "113-0000000-0000000"})
+	if err != nil {
+		t.Fatalf("FetchInvoice failed: %v", err)
+	}
+	if invoice.OrderID != "113-0000000-0000000" || len(invoice.Payments) != 1 || invoice.Payments[0].OrderID != "113-0000000-0000000" {
+		t.Errorf("Expected the order's ID on the invoice and its payments, got %q %+v", invoice.OrderID, invoice.Payments)
+	}
+}
@@ -0,0 +1,226 @@
+package amazon
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// OrderIterator yields orders one at a time as their list page is read and,
+// with IncludeDetails, as each order's details are parsed. Unlike FetchOrders
+// it holds at most one order list page in memory and fetches details one
+// order at a time.
+//
+//	it := client.Orders(ctx, opts)
+//	defer it.Close()
+//	for it.Next() {
+//		order := it.Order()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type OrderIterator struct {
+	client *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   FetchOptions
+	parser *Parser
+
+	filters []string // Resolved on the first call to Next
+	kinds   []OrderKind
+	list    int // Index of the next filter and kind pair to read
+	cursor  *orderListCursor
+	pending []*OrderSummary // Orders from the current page not yet yielded
+
+	order    *Order
+	position OrderListPosition
+	yielded  int
+	totals   []OrderListTotal
+	errs     OrderErrors
+	err      error
+	closed   atomic.Bool
+}
+
+// OrderListPosition identifies the order list page an order came from
+type OrderListPosition struct {
+	Filter string    // Time filter, e.g. "year-2025" or "months-3"
+	Year   int       // Year of a year filter, 0 otherwise
+	Kind   OrderKind // Physical or digital order list
+	Page   int       // Page number within the list, starting at 1
+}
+
+// Orders returns an iterator over the orders selected by opts. Nothing is
+// fetched until the first call to Next.
+func (c *Client) Orders(ctx context.Context, opts FetchOptions) *OrderIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	return &OrderIterator{
+		client: c,
+		ctx:    ctx,
+		cancel: cancel,
+		opts:   opts,
+		parser: c.newParser(),
+		kinds:  orderKinds(opts),
+	}
+}
+
+// Next advances to the next order, fetching list pages and details as
+// needed. It returns false when there are no more orders, the iterator was
+// closed, or an error stopped it; check Err afterwards.
+func (it *OrderIterator) Next() bool {
+	it.order = nil
+	if it.closed.Load() || it.err != nil {
+		return false
+	}
+	if it.opts.MaxOrders > 0 && it.yielded >= it.opts.MaxOrders {
+		return false
+	}
+
+	for len(it.pending) == 0 {
+		if err := it.ctx.Err(); err != nil {
+			return it.stop(err)
+		}
+		if !it.nextPage() {
+			return false
+		}
+	}
+
+	summary := it.pending[0]
+	it.pending = it.pending[1:]
+	it.yielded++
+
+	if !it.opts.IncludeDetails {
+		it.order = orderFromSummary(summary)
+		return true
+	}
+
+	it.client.logger.Debug("fetching order details", "orderID", summary.ID)
+
+	order, err := it.client.fetchOrderDetails(it.ctx, summary.ID, it.parser)
+	// The number of orders is not known until the last page has been read
+	it.client.hooks.detail(DetailEvent{
+		PageType: PageTypeOrderDetails,
+		OrderID:  summary.ID,
+		Err:      err,
+		Done:     it.yielded,
+	})
+	if err != nil {
+		if isBlockedPageError(err) || it.ctx.Err() != nil {
+			return it.stop(err)
+		}
+		it.client.logger.Warn("failed to fetch order details",
+			"orderID", summary.ID,
+			"error", err,
+		)
+		// Use summary data as fallback
+		it.errs = append(it.errs, &OrderError{OrderID: summary.ID, Err: err})
+		it.order = orderFromSummary(summary)
+		return true
+	}
+
+	it.order = mergeOrderSummary(order, summary)
+	return true
+}
+
+// nextPage reads the next order list page into pending, moving on to the
+// next list when the current one is done. It returns false when every list
+// has been read or an error stopped the iterator.
+func (it *OrderIterator) nextPage() bool {
+	if it.filters == nil {
+		filters, err := it.client.determineTimeFilters(it.ctx, it.opts)
+		if err != nil {
+			return it.stop(err)
+		}
+		it.filters = filters
+	}
+
+	if it.cursor == nil || it.cursor.done() {
+		if it.cursor != nil {
+			it.totals = append(it.totals, it.cursor.total)
+			it.client.checkOrderListTotal(it.cursor.total, it.opts)
+		}
+		if it.list >= len(it.filters)*len(it.kinds) {
+			it.cursor = nil
+			return false
+		}
+		filter, kind := it.filters[it.list/len(it.kinds)], it.kinds[it.list%len(it.kinds)]
+		it.list++
+		it.cursor = it.client.newOrderListCursor(filter, kind, it.parser)
+	}
+
+	summaries, err := it.cursor.next(it.ctx)
+	if err != nil {
+		// Other lists will be blocked the same way, so give up early
+		if isBlockedPageError(err) || it.ctx.Err() != nil {
+			return it.stop(err)
+		}
+		it.client.logger.Warn("failed to fetch orders",
+			"filter", it.cursor.filter,
+			"kind", it.cursor.kind,
+			"error", err,
+		)
+		// Skip the rest of this list, as FetchOrders does. Its total is
+		// left out of Totals since the list was only partly read.
+		it.cursor = nil
+		return true
+	}
+
+	it.position = OrderListPosition{
+		Filter: it.cursor.filter,
+		Year:   filterYear(it.cursor.filter),
+		Kind:   it.cursor.kind,
+		Page:   it.cursor.page,
+	}
+
+	for _, summary := range summaries {
+		if it.client.isWithinDateRange(summary.Date, it.opts) {
+			it.pending = append(it.pending, summary)
+		}
+	}
+	return true
+}
+
+// stop ends the iteration with err, unless Close already ended it
+func (it *OrderIterator) stop(err error) bool {
+	if !it.closed.Load() {
+		it.err = err
+	}
+	return false
+}
+
+// Order returns the current order. It is only valid after Next returned true.
+func (it *OrderIterator) Order() *Order {
+	return it.order
+}
+
+// Position returns the order list, year and page the current order came from
+func (it *OrderIterator) Position() OrderListPosition {
+	return it.position
+}
+
+// Totals returns the order count reported and collected for each order list
+// read so far. Lists are added once all their pages have been read; lists
+// that failed part way are left out.
+func (it *OrderIterator) Totals() []OrderListTotal {
+	return it.totals
+}
+
+// Err returns the error that stopped the iterator. If iteration finished but
+// some orders' details could not be fetched, it returns an OrderErrors; those
+// orders were yielded with their list page data only.
+func (it *OrderIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if len(it.errs) > 0 {
+		return it.errs
+	}
+	return nil
+}
+
+// Close stops the iteration early and cancels any request in flight. It may
+// be called from another goroutine, more than once, and after iteration has
+// finished.
+func (it *OrderIterator) Close() {
+	it.closed.Store(true)
+	it.cancel()
+}
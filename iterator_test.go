@@ -0,0 +1,197 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// newPagedFakeAmazon serves a 2025 order list of seven orders over three
+// linked pages and records which pages were requested. The second order's
+// details always fail.
+func newPagedFakeAmazon(t *testing.T, opts ...Option) (*Client, func() []string) {
+	t.Helper()
+	pages := map[string]string{
+		"":  orderListPage([]string{"114-0000000-0000001", "114-0000000-0000002", "114-0000000-0000003"}, 7, "/your-orders/orders?timeFilter=year-2025&startIndex=3", true),
+		"3": orderListPage([]string{"114-0000000-0000004", "114-0000000-0000005", "114-0000000-0000006"}, 7, "/your-orders/orders?timeFilter=year-2025&startIndex=6", true),
+		"6": orderListPage([]string{"114-0000000-0000007"}, 7, "", true),
+	}
+
+	var mu sync.Mutex
+	var requested []string
+	mux := http.NewServeMux()
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) {
+		startIndex := r.URL.Query().Get("startIndex")
+		mu.Lock()
+		requested = append(requested, startIndex)
+		mu.Unlock()
+		w.Write([]byte(pages[startIndex]))
+	})
+	mux.HandleFunc(orderDetailsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("orderID") == "114-0000000-0000002" {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(testOrderDetailsPage))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(append([]Option{
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+		WithMaxRetries(0),
+	}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requested...)
+	}
+}
+
+func TestOrderIterator(t *testing.T) {
+	client, _ := newPagedFakeAmazon(t)
+
+	it := client.Orders(context.Background(), FetchOptions{Year: 2025})
+	defer it.Close()
+
+	var pages []int
+	count := 0
+	for it.Next() {
+		count++
+		if it.Order() == nil || it.Order().ID == "" {
+			t.Fatalf("Order %d has no ID", count)
+		}
+		pos := it.Position()
+		if pos.Filter != "year-2025" || pos.Year != 2025 || pos.Kind != OrderKindPhysical {
+			t.Errorf("Unexpected position %+v", pos)
+		}
+		pages = append(pages, pos.Page)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iteration failed: %v", err)
+	}
+
+	if count != 7 {
+		t.Errorf("Expected 7 orders, got %d", count)
+	}
+	if expected := []int{1, 1, 1, 2, 2, 2, 3}; !reflect.DeepEqual(pages, expected) {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+	if totals := it.Totals(); len(totals) != 1 || totals[0].Reported != 7 || !totals[0].Complete() {
+		t.Errorf("Unexpected totals %+v", totals)
+	}
+	if it.Next() {
+		t.Error("Expected Next to stay false after the last order")
+	}
+}
+
+func TestOrderIterator_FailedListTotal(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ordersPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startIndex") == "3" {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(orderListPage([]string{"114-0000000-0000001", "114-0000000-0000002", "114-0000000-0000003"}, 7, "/your-orders/orders?timeFilter=year-2025&startIndex=3", true)))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+		WithMaxRetries(0),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	it := client.Orders(context.Background(), FetchOptions{Year: 2025})
+	defer it.Close()
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected the 3 orders read before the failure, got %d", count)
+	}
+	if totals := it.Totals(); len(totals) != 0 {
+		t.Errorf("Expected no total for the partly read list, got %+v", totals)
+	}
+}
+
+func TestOrderIterator_EarlyTermination(t *testing.T) {
+	client, requested := newPagedFakeAmazon(t)
+
+	it := client.Orders(context.Background(), FetchOptions{Year: 2025})
+	for i := 0; i < 2 && it.Next(); i++ {
+	}
+	it.Close()
+
+	if it.Next() {
+		t.Error("Expected Next to return false after Close")
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Expected no error after Close, got %v", err)
+	}
+	if pages := requested(); len(pages) != 1 {
+		t.Errorf("Expected only the first page to be fetched, got %v", pages)
+	}
+
+	// MaxOrders also stops before later pages are read
+	client, requested = newPagedFakeAmazon(t)
+	it = client.Orders(context.Background(), FetchOptions{Year: 2025, MaxOrders: 3})
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 3 || len(requested()) != 1 {
+		t.Errorf("Expected 3 orders from 1 page, got %d orders from %d pages", count, len(requested()))
+	}
+}
+
+func TestOrderIterator_Details(t *testing.T) {
+	client, _ := newPagedFakeAmazon(t)
+
+	it := client.Orders(context.Background(), FetchOptions{Year: 2025, IncludeDetails: true, MaxOrders: 3})
+	defer it.Close()
+
+	var orders []*Order
+	for it.Next() {
+		orders = append(orders, it.Order())
+	}
+	if len(orders) != 3 {
+		t.Fatalf("Expected 3 orders, got %d", len(orders))
+	}
+	if len(orders[0].Items) != 1 {
+		t.Errorf("Expected details with items for the first order, got %d items", len(orders[0].Items))
+	}
+	// The second order's details failed; it is yielded from its list page data
+	if orders[1].ID != "114-0000000-0000002" || len(orders[1].Items) != 0 {
+		t.Errorf("Expected summary-only second order, got %s with %d items", orders[1].ID, len(orders[1].Items))
+	}
+
+	var orderErrs OrderErrors
+	if err := it.Err(); !errors.As(err, &orderErrs) || len(orderErrs) != 1 || orderErrs[0].OrderID != "114-0000000-0000002" {
+		t.Errorf("Expected OrderErrors for 114-0000000-0000002, got %v", err)
+	}
+}
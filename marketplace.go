@@ -0,0 +1,248 @@
+package amazon
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Marketplace describes a regional Amazon site: its domain, the cookies it
+// uses for authentication and how it formats prices and dates.
+type Marketplace struct {
+	ID              string            // Short code, e.g. "US" or "UK"
+	Domain          string            // Site host, e.g. "www.amazon.co.uk"
+	CookieDomain    string            // Domain cookies are scoped to, e.g. ".amazon.co.uk"
+	CookieSuffix    string            // Suffix of the account cookies, e.g. "main" for at-main or "acbuk" for at-acbuk
+	Currency        string            // ISO 4217 currency code, e.g. "GBP"
+	CurrencySymbols []string          // Symbols and codes that may appear in prices, longest first
+	DecimalComma    bool              // Prices are written 1.234,56 rather than 1,234.56
+	AcceptLanguage  string            // Accept-Language header sent with requests
+	DateFormats     []string          // time.Parse layouts for dates, after month names are translated
+	DatePrefixes    []string          // Labels that precede dates, e.g. "Order placed"
+	MonthNames      map[string]string // Localized month names mapped to English, lowercase
+}
+
+// englishDatePrefixes are the date labels shown when a site is viewed in English
+var englishDatePrefixes = []string{"Ordered", "Order placed", "Placed on"}
+
+// Supported marketplaces
+var (
+	MarketplaceUS = &Marketplace{
+		ID:              "US",
+		Domain:          "www.amazon.com",
+		CookieDomain:    ".amazon.com",
+		CookieSuffix:    "main",
+		Currency:        "USD",
+		CurrencySymbols: []string{"USD", "US$", "$"},
+		AcceptLanguage:  "en-US,en;q=0.9",
+		DateFormats: []string{
+			"January 2, 2006",
+			"Jan 2, 2006",
+			"January 02, 2006",
+			"Jan 02, 2006",
+			"2 January 2006",
+			"02 January 2006",
+			"2006-01-02",
+			"01/02/2006",
+			"1/2/2006",
+		},
+		DatePrefixes: englishDatePrefixes,
+	}
+
+	MarketplaceUK = &Marketplace{
+		ID:              "UK",
+		Domain:          "www.amazon.co.uk",
+		CookieDomain:    ".amazon.co.uk",
+		CookieSuffix:    "acbuk",
+		Currency:        "GBP",
+		CurrencySymbols: []string{"GBP", "£"},
+		AcceptLanguage:  "en-GB,en;q=0.9",
+		DateFormats: []string{
+			"2 January 2006",
+			"02 January 2006",
+			"2 Jan 2006",
+			"January 2, 2006",
+			"2006-01-02",
+			"02/01/2006",
+			"2/1/2006",
+		},
+		DatePrefixes: englishDatePrefixes,
+	}
+
+	MarketplaceDE = &Marketplace{
+		ID:              "DE",
+		Domain:          "www.amazon.de",
+		CookieDomain:    ".amazon.de",
+		CookieSuffix:    "acbde",
+		Currency:        "EUR",
+		CurrencySymbols: []string{"EUR", "€"},
+		DecimalComma:    true,
+		AcceptLanguage:  "de-DE,de;q=0.9,en;q=0.8",
+		DateFormats: []string{
+			"2. January 2006",
+			"2 January 2006",
+			"02.01.2006",
+			"2.1.2006",
+			"2006-01-02",
+		},
+		DatePrefixes: append([]string{"Bestellung aufgegeben", "Bestellt am", "Bestelldatum"}, englishDatePrefixes...),
+		MonthNames: map[string]string{
+			"januar":    "january",
+			"jänner":    "january",
+			"februar":   "february",
+			"märz":      "march",
+			"april":     "april",
+			"mai":       "may",
+			"juni":      "june",
+			"juli":      "july",
+			"august":    "august",
+			"september": "september",
+			"oktober":   "october",
+			"november":  "november",
+			"dezember":  "december",
+		},
+	}
+
+	MarketplaceCA = &Marketplace{
+		ID:              "CA",
+		Domain:          "www.amazon.ca",
+		CookieDomain:    ".amazon.ca",
+		CookieSuffix:    "acbca",
+		Currency:        "CAD",
+		CurrencySymbols: []string{"CDN$", "CAD", "C$", "$"},
+		AcceptLanguage:  "en-CA,en;q=0.9",
+		DateFormats: []string{
+			"January 2, 2006",
+			"Jan 2, 2006",
+			"2 January 2006",
+			"2006-01-02",
+		},
+		DatePrefixes: englishDatePrefixes,
+	}
+
+	MarketplaceJP = &Marketplace{
+		ID:              "JP",
+		Domain:          "www.amazon.co.jp",
+		CookieDomain:    ".amazon.co.jp",
+		CookieSuffix:    "acbjp",
+		Currency:        "JPY",
+		CurrencySymbols: []string{"JPY", "￥", "¥", "円"},
+		AcceptLanguage:  "ja-JP,ja;q=0.9,en;q=0.8",
+		DateFormats: []string{
+			"2006年1月2日",
+			"2006/01/02",
+			"2006/1/2",
+			"2006-01-02",
+			"January 2, 2006",
+		},
+		DatePrefixes: append([]string{"注文確定日", "注文日"}, englishDatePrefixes...),
+	}
+)
+
+// Marketplaces returns all supported marketplaces
+func Marketplaces() []*Marketplace {
+	return []*Marketplace{MarketplaceUS, MarketplaceUK, MarketplaceDE, MarketplaceCA, MarketplaceJP}
+}
+
+// MarketplaceByID looks up a marketplace by its ID (e.g. "uk") or domain
+// (e.g. "amazon.co.uk"), ignoring case
+func MarketplaceByID(id string) (*Marketplace, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, m := range Marketplaces() {
+		if id == strings.ToLower(m.ID) || id == m.Domain || "www."+id == m.Domain {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown marketplace %q", id)
+}
+
+// marketplaceForHost returns the marketplace serving a host, or nil
+func marketplaceForHost(host string) *Marketplace {
+	host = strings.ToLower(host)
+	for _, m := range Marketplaces() {
+		if host == m.Domain || host == m.CookieDomain[1:] || strings.HasSuffix(host, m.CookieDomain) {
+			return m
+		}
+	}
+	return nil
+}
+
+// BaseURL returns the marketplace's site URL, e.g. "https://www.amazon.co.uk"
+func (m *Marketplace) BaseURL() string {
+	return "https://" + m.Domain
+}
+
+// EssentialCookies returns the cookie names needed to authenticate with the marketplace
+func (m *Marketplace) EssentialCookies() []string {
+	names := []string{"session-id", "session-id-time", "session-token"}
+	for _, prefix := range []string{"ubid", "at", "sess-at", "sst", "x", "lc"} {
+		names = append(names, prefix+"-"+m.CookieSuffix)
+	}
+	return append(names, "i18n-prefs")
+}
+
+var numberPattern = regexp.MustCompile(`\d[\d.,]*`)
+
+// ParsePrice extracts an amount in the marketplace's currency from text like
+// "£1,234.56", "1.234,56 €" or "￥1,234". The sign is ignored; it returns zero
+// if no number is found.
+func (m *Marketplace) ParsePrice(text string) Money {
+	return parseMoneyText(text, m.Currency, m.DecimalComma)
+}
+
+// findPrice returns the first amount in text that is marked with one of the
+// marketplace's currency symbols, skipping bare numbers such as dates
+func (m *Marketplace) findPrice(text string) (Money, bool) {
+	for _, loc := range numberPattern.FindAllStringIndex(text, -1) {
+		before := strings.TrimRight(text[:loc[0]], " \u00a0")
+		after := strings.TrimLeft(text[loc[1]:], " \u00a0")
+		for _, symbol := range m.CurrencySymbols {
+			if strings.HasSuffix(before, symbol) || strings.HasPrefix(after, symbol) {
+				return m.ParsePrice(text[loc[0]:loc[1]]), true
+			}
+		}
+	}
+	return Money{Currency: m.Currency}, false
+}
+
+// hasCurrency reports whether text contains one of the marketplace's currency symbols
+func (m *Marketplace) hasCurrency(text string) bool {
+	for _, symbol := range m.CurrencySymbols {
+		if strings.Contains(text, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDate parses a date as shown on the marketplace's order pages,
+// e.g. "Order placed 26 November 2025", "26. November 2025" or "2025年11月26日"
+func (m *Marketplace) ParseDate(text string) (time.Time, error) {
+	text = strings.TrimSpace(text)
+
+	// Remove common prefixes
+	for _, prefix := range m.DatePrefixes {
+		text = strings.TrimPrefix(text, prefix)
+		text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), ":"))
+	}
+
+	// Translate localized month names so time.Parse can read them
+	if len(m.MonthNames) > 0 {
+		words := strings.Fields(text)
+		for i, word := range words {
+			if english, ok := m.MonthNames[strings.ToLower(word)]; ok {
+				words[i] = english
+			}
+		}
+		text = strings.Join(words, " ")
+	}
+
+	for _, format := range m.DateFormats {
+		if t, err := time.Parse(format, text); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", text)
+}
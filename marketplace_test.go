@@ -0,0 +1,175 @@
+package amazon
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarketplace_ParsePrice(t *testing.T) {
+	tests := []struct {
+		marketplace *Marketplace
+		text        string
+		expected    Money
+	}{
+		{MarketplaceUS, "$1,234.56", NewMoney(123456, "USD")},
+		{MarketplaceUK, "£1,234.56", NewMoney(123456, "GBP")},
+		{MarketplaceDE, "1.234,56 €", NewMoney(123456, "EUR")},
+		{MarketplaceDE, "EUR 12,99", NewMoney(1299, "EUR")},
+		{MarketplaceCA, "CDN$ 42.10", NewMoney(4210, "CAD")},
+		{MarketplaceJP, "￥1,234", NewMoney(1234, "JPY")},
+		{MarketplaceJP, "2,980円", NewMoney(2980, "JPY")},
+		{MarketplaceUK, "invalid", NewMoney(0, "GBP")},
+	}
+
+	for _, tc := range tests {
+		result := tc.marketplace.ParsePrice(tc.text)
+		if result != tc.expected {
+			t.Errorf("%s ParsePrice(%q) = %s, want %s", tc.marketplace.ID, tc.text, result, tc.expected)
+		}
+	}
+}
+
+func TestMarketplace_ParseDate(t *testing.T) {
+	expected := time.Date(2025, time.November, 26, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		marketplace *Marketplace
+		text        string
+	}{
+		{MarketplaceUS, "Order placed November 26, 2025"},
+		{MarketplaceUK, "Order placed 26 November 2025"},
+		{MarketplaceUK, "26/11/2025"},
+		{MarketplaceDE, "Bestellung aufgegeben 26. November 2025"},
+		{MarketplaceDE, "26. november 2025"},
+		{MarketplaceDE, "26.11.2025"},
+		{MarketplaceCA, "Ordered November 26, 2025"},
+		{MarketplaceJP, "注文日 2025年11月26日"},
+		{MarketplaceJP, "2025/11/26"},
+	}
+
+	for _, tc := range tests {
+		result, err := tc.marketplace.ParseDate(tc.text)
+		if err != nil {
+			t.Errorf("%s ParseDate(%q) failed: %v", tc.marketplace.ID, tc.text, err)
+			continue
+		}
+		if !result.Equal(expected) {
+			t.Errorf("%s ParseDate(%q) = %v, want %v", tc.marketplace.ID, tc.text, result, expected)
+		}
+	}
+
+	// Localized month names are translated as whole words only
+	if date, err := MarketplaceDE.ParseDate("1. März 2024"); err != nil || date.Month() != time.March {
+		t.Errorf("Expected March, got %v (%v)", date, err)
+	}
+	if _, err := MarketplaceUS.ParseDate("26/11/2025"); err == nil {
+		t.Error("Expected day-first date to fail for amazon.com")
+	}
+}
+
+func TestMarketplace_ParseDate_NonDates(t *testing.T) {
+	texts := []string{
+		"Order # 114-3941689-8772232",
+		"114-3941689-8772232",
+		"Ship to",
+		"Ship to Jane Doe",
+		"Total $44.91",
+		"Order placed",
+		"Qty: 2",
+		"2025",
+		"1/2",
+		"",
+	}
+	for _, m := range Marketplaces() {
+		for _, text := range texts {
+			if date, err := m.ParseDate(text); err == nil {
+				t.Errorf("%s ParseDate(%q) = %v, want error", m.ID, text, date)
+			}
+		}
+	}
+}
+
+func TestMarketplace_EssentialCookies(t *testing.T) {
+	cookies := strings.Join(MarketplaceUK.EssentialCookies(), " ")
+	for _, name := range []string{"session-id", "ubid-acbuk", "at-acbuk", "x-acbuk"} {
+		if !strings.Contains(cookies, name) {
+			t.Errorf("Expected UK essential cookies to include %s, got %s", name, cookies)
+		}
+	}
+	if strings.Contains(cookies, "-main") {
+		t.Errorf("Expected no amazon.com cookies for UK, got %s", cookies)
+	}
+}
+
+func TestMarketplaceByID(t *testing.T) {
+	for id, expected := range map[string]*Marketplace{
+		"us":            MarketplaceUS,
+		"UK":            MarketplaceUK,
+		"amazon.de":     MarketplaceDE,
+		"www.amazon.ca": MarketplaceCA,
+		"jp":            MarketplaceJP,
+	} {
+		m, err := MarketplaceByID(id)
+		if err != nil || m != expected {
+			t.Errorf("MarketplaceByID(%q) = %v, %v", id, m, err)
+		}
+	}
+
+	if _, err := MarketplaceByID("fr"); err == nil {
+		t.Error("Expected error for unsupported marketplace")
+	}
+}
+
+func TestExtractFromCurl_Marketplace(t *testing.T) {
+	curlCmd := `curl 'https://www.amazon.co.uk/your-orders/orders' -b 'session-id=123-456; ubid-acbuk=abc; at-acbuk=Atza|token; session-token=tok'`
+
+	cookies, err := ExtractFromCurl(curlCmd)
+	if err != nil {
+		t.Fatalf("ExtractFromCurl failed: %v", err)
+	}
+	for _, c := range cookies {
+		if c.Domain != ".amazon.co.uk" {
+			t.Errorf("Expected cookie %s scoped to .amazon.co.uk, got %s", c.Name, c.Domain)
+		}
+	}
+
+	store := &CookieStore{cookies: make(map[string]*Cookie)}
+	for _, c := range cookies {
+		store.Set(c)
+	}
+	if !store.HasEssentialCookiesFor(MarketplaceUK) {
+		t.Error("Expected UK essential cookies to be present")
+	}
+	if store.HasEssentialCookies() {
+		t.Error("Expected amazon.com essential cookies to be missing")
+	}
+}
+
+func TestParseOrderList_Marketplace(t *testing.T) {
+	html := `<html><body>
+<div class="order-card">
+	<ul><li class="order-header__header-list-item">Bestellung aufgegeben 26. November 2025</li>
+	<li class="order-header__header-list-item">Summe 1.044,91 €</li></ul>
+	<a href="/your-orders/order-details?orderID=302-9733092-9360267">Bestelldetails anzeigen</a>
+</div>
+</body></html>`
+
+	orders, err := NewMarketplaceParser(MarketplaceDE).ParseOrderList(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("ParseOrderList failed: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("Expected 1 order, got %d", len(orders))
+	}
+
+	order := orders[0]
+	if order.Total != NewMoney(104491, "EUR") {
+		t.Errorf("Expected total 1044.91 EUR, got %s", order.Total)
+	}
+	if order.Date.Month() != time.November || order.Date.Day() != 26 {
+		t.Errorf("Expected date November 26, got %v", order.Date)
+	}
+	if !strings.HasPrefix(order.DetailURL, "https://www.amazon.de/") {
+		t.Errorf("Expected amazon.de detail URL, got %s", order.DetailURL)
+	}
+}
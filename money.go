@@ -0,0 +1,303 @@
+package amazon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in a currency's minor units (e.g. cents), so
+// sums and line totals reconcile to the cent without float rounding errors
+type Money struct {
+	Units    int64  // Amount in minor units, e.g. 4491 for $44.91
+	Currency string // ISO 4217 code, e.g. "USD"; empty if unknown
+}
+
+// zeroDecimalCurrencies have no minor units, e.g. ¥1,234 is 1234 units
+var zeroDecimalCurrencies = map[string]bool{
+	"JPY": true,
+	"KRW": true,
+	"CLP": true,
+	"VND": true,
+}
+
+// currencyDigits returns how many decimal places a currency's amounts have.
+// Unknown currencies are assumed to have two.
+func currencyDigits(currency string) int {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return 0
+	}
+	return 2
+}
+
+// NewMoney creates an amount from minor units, e.g. NewMoney(4491, "USD") is $44.91
+func NewMoney(units int64, currency string) Money {
+	return Money{Units: units, Currency: currency}
+}
+
+// ParseMoney parses a plain decimal amount like "44.91" or "-3.5".
+// Digits beyond the currency's minor units are rounded half away from zero.
+func ParseMoney(amount, currency string) (Money, error) {
+	s := strings.TrimSpace(amount)
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return Money{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("invalid amount: %q", amount)
+	}
+
+	// Pad or round the fraction to exactly the currency's minor units
+	digits := currencyDigits(currency)
+	roundUp := false
+	if len(frac) > digits {
+		roundUp = frac[digits] >= '5'
+		frac = frac[:digits]
+	}
+	frac += strings.Repeat("0", digits-len(frac))
+
+	units, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount: %q: %w", amount, err)
+	}
+	if roundUp {
+		units++
+	}
+	if negative {
+		units = -units
+	}
+
+	return Money{Units: units, Currency: currency}, nil
+}
+
+// abs returns the absolute value of n
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// isDigits reports whether s contains only ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MoneyFromFloat converts a float amount, rounding to the nearest minor unit
+func MoneyFromFloat(amount float64, currency string) Money {
+	scale := math.Pow10(currencyDigits(currency))
+	return Money{Units: int64(math.Round(amount * scale)), Currency: currency}
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Units == 0
+}
+
+// Add returns m + o. An unknown currency takes the other amount's currency.
+// The currencies are not compared; use CheckedAdd for amounts that may differ.
+func (m Money) Add(o Money) Money {
+	return Money{Units: m.Units + o.Units, Currency: m.currencyWith(o)}
+}
+
+// Sub returns m - o. An unknown currency takes the other amount's currency.
+// The currencies are not compared; use CheckedSub for amounts that may differ.
+func (m Money) Sub(o Money) Money {
+	return Money{Units: m.Units - o.Units, Currency: m.currencyWith(o)}
+}
+
+// CheckedAdd returns m + o, or an error wrapping ErrCurrencyMismatch if both
+// currencies are known and differ
+func (m Money) CheckedAdd(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return m.Add(o), nil
+}
+
+// CheckedSub returns m - o, or an error wrapping ErrCurrencyMismatch if both
+// currencies are known and differ
+func (m Money) CheckedSub(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return m.Sub(o), nil
+}
+
+// checkCurrency returns an error if m and o are in different known currencies
+func (m Money) checkCurrency(o Money) error {
+	if m.Currency != "" && o.Currency != "" && m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m, o)
+	}
+	return nil
+}
+
+// currencyWith returns m's currency, or o's if m's is unknown
+func (m Money) currencyWith(o Money) string {
+	if m.Currency == "" {
+		return o.Currency
+	}
+	return m.Currency
+}
+
+// Mul returns the amount multiplied by a quantity, rounded to the nearest minor unit
+func (m Money) Mul(quantity float64) Money {
+	return Money{Units: int64(math.Round(float64(m.Units) * quantity)), Currency: m.Currency}
+}
+
+// quantityScale is the precision quantities are divided at, e.g. 2.315 lb
+const quantityScale = 1000
+
+// Div returns the amount divided by a quantity in integer minor units,
+// rounded half away from zero. Quantities are taken to three decimal places;
+// a quantity that rounds to zero or below gives zero.
+func (m Money) Div(quantity float64) Money {
+	q := int64(math.Round(quantity * quantityScale))
+	if q <= 0 {
+		return Money{Currency: m.Currency}
+	}
+	n := m.Units * quantityScale
+	units := n / q
+	if rem := n % q; 2*abs(rem) >= q {
+		if n < 0 {
+			units--
+		} else {
+			units++
+		}
+	}
+	return Money{Units: units, Currency: m.Currency}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{Units: -m.Units, Currency: m.Currency}
+}
+
+// Float64 returns the amount in major units, e.g. 44.91.
+// It is provided for compatibility; use Units for exact arithmetic.
+func (m Money) Float64() float64 {
+	return float64(m.Units) / math.Pow10(currencyDigits(m.Currency))
+}
+
+// Decimal formats the amount in major units without a currency, e.g. "44.91" or "1234" for JPY
+func (m Money) Decimal() string {
+	digits := currencyDigits(m.Currency)
+	units := m.Units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	s := strconv.FormatInt(units, 10)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// String formats the amount with its currency, e.g. "44.91 USD"
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+// moneyJSON is the JSON form of Money. The amount is written as an exact decimal number.
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: json.Number(m.Decimal()), Currency: m.Currency})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It also accepts a bare number,
+// as written for amounts before Money was introduced.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var v moneyJSON
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, &v.Amount); err != nil {
+		return fmt.Errorf("invalid money value: %w", err)
+	}
+
+	if v.Amount == "" {
+		*m = Money{Currency: v.Currency}
+		return nil
+	}
+
+	// Exponent notation is only produced by float encoders, so round through float64
+	if strings.ContainsAny(string(v.Amount), "eE") {
+		f, err := v.Amount.Float64()
+		if err != nil {
+			return fmt.Errorf("invalid money value: %w", err)
+		}
+		*m = MoneyFromFloat(f, v.Currency)
+		return nil
+	}
+
+	parsed, err := ParseMoney(string(v.Amount), v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// parseMoneyText extracts the first amount from text like "$1,234.56" or
+// "1.234,56 €", ignoring any sign. It returns zero if no number is found.
+func parseMoneyText(text, currency string, decimalComma bool) Money {
+	number := numberPattern.FindString(text)
+	if number == "" {
+		return Money{Currency: currency}
+	}
+
+	// Drop thousands separators and normalize the decimal point
+	if decimalComma {
+		number = strings.ReplaceAll(number, ".", "")
+		number = strings.ReplaceAll(number, ",", ".")
+	} else {
+		number = strings.ReplaceAll(number, ",", "")
+	}
+	number = strings.TrimRight(number, ".")
+
+	m, err := ParseMoney(number, currency)
+	if err != nil {
+		return Money{Currency: currency}
+	}
+	return m
+}
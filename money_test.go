@@ -0,0 +1,156 @@
+package amazon
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		expected int64
+	}{
+		{"44.91", "USD", 4491},
+		{"44.9", "USD", 4490},
+		{"44", "USD", 4400},
+		{".5", "USD", 50},
+		{"-3.50", "USD", -350},
+		{"0.005", "USD", 1},
+		{"0.004", "USD", 0},
+		{"1234", "JPY", 1234},
+		{"1234.6", "JPY", 1235},
+		{"12.34", "", 1234},
+	}
+
+	for _, tc := range tests {
+		m, err := ParseMoney(tc.amount, tc.currency)
+		if err != nil {
+			t.Errorf("ParseMoney(%q) failed: %v", tc.amount, err)
+			continue
+		}
+		if m.Units != tc.expected || m.Currency != tc.currency {
+			t.Errorf("ParseMoney(%q, %q) = %+v, want %d units", tc.amount, tc.currency, m, tc.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "abc", "1.2.3", "$5", "-"} {
+		if _, err := ParseMoney(invalid, "USD"); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	// 3 x 19.99 and 0.10 + 0.20 are inexact as float64
+	unit := NewMoney(1999, "USD")
+	if line := unit.Mul(3); line != NewMoney(5997, "USD") {
+		t.Errorf("Expected 59.97 USD, got %s", line)
+	}
+
+	sum := NewMoney(10, "USD").Add(NewMoney(20, "USD"))
+	if sum.Decimal() != "0.30" {
+		t.Errorf("Expected 0.30, got %s", sum.Decimal())
+	}
+
+	// A zero value takes the other amount's currency
+	var total Money
+	total = total.Add(NewMoney(4491, "EUR"))
+	if total != NewMoney(4491, "EUR") {
+		t.Errorf("Expected 44.91 EUR, got %s", total)
+	}
+
+	if diff := total.Sub(NewMoney(5000, "EUR")); diff.Decimal() != "-5.09" {
+		t.Errorf("Expected -5.09, got %s", diff.Decimal())
+	}
+
+	// Checked arithmetic refuses to mix currencies
+	if _, err := NewMoney(100, "USD").CheckedAdd(NewMoney(100, "GBP")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch for USD + GBP, got %v", err)
+	}
+	if _, err := NewMoney(100, "USD").CheckedSub(NewMoney(100, "GBP")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch for USD - GBP, got %v", err)
+	}
+	if sum, err := (Money{}).CheckedAdd(NewMoney(100, "GBP")); err != nil || sum != NewMoney(100, "GBP") {
+		t.Errorf("Expected 1.00 GBP, got %s, %v", sum, err)
+	}
+	if diff, err := NewMoney(300, "USD").CheckedSub(NewMoney(100, "USD")); err != nil || diff != NewMoney(200, "USD") {
+		t.Errorf("Expected 2.00 USD, got %s, %v", diff, err)
+	}
+
+	// Division stays in minor units and rounds half away from zero
+	divisions := []struct {
+		amount   Money
+		quantity float64
+		expected int64
+	}{
+		{NewMoney(898, "USD"), 2, 449},
+		{NewMoney(1000, "USD"), 3, 333},
+		{NewMoney(500, "USD"), 3, 167},
+		{NewMoney(159, "USD"), 2.31, 69},
+		{NewMoney(-500, "USD"), 3, -167},
+		{NewMoney(898, "USD"), 0, 0},
+		{NewMoney(898, "USD"), -1, 0},
+	}
+	for _, tc := range divisions {
+		if got := tc.amount.Div(tc.quantity); got != NewMoney(tc.expected, "USD") {
+			t.Errorf("%s / %v = %s, want %d", tc.amount, tc.quantity, got, tc.expected)
+		}
+	}
+}
+
+func TestMoney_Format(t *testing.T) {
+	tests := []struct {
+		money    Money
+		expected string
+	}{
+		{NewMoney(4491, "USD"), "44.91 USD"},
+		{NewMoney(5, "GBP"), "0.05 GBP"},
+		{NewMoney(-105, "EUR"), "-1.05 EUR"},
+		{NewMoney(1234, "JPY"), "1234 JPY"},
+		{NewMoney(0, ""), "0.00"},
+	}
+
+	for _, tc := range tests {
+		if result := tc.money.String(); result != tc.expected {
+			t.Errorf("String() = %q, want %q", result, tc.expected)
+		}
+	}
+
+	if f := NewMoney(1234, "JPY").Float64(); f != 1234 {
+		t.Errorf("Expected 1234, got %v", f)
+	}
+	if f := NewMoney(4491, "USD").Float64(); f != 44.91 {
+		t.Errorf("Expected 44.91, got %v", f)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(4491, "USD"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"amount":44.91,"currency":"USD"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	var m Money
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if m != NewMoney(4491, "USD") {
+		t.Errorf("Expected round trip to 44.91 USD, got %s", m)
+	}
+
+	// Amounts written before Money was introduced are bare numbers
+	var legacy struct {
+		Total Money `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(`{"total": 44.91}`), &legacy); err != nil {
+		t.Fatalf("Unmarshal legacy failed: %v", err)
+	}
+	if legacy.Total != NewMoney(4491, "") {
+		t.Errorf("Expected 4491 units from legacy amount, got %+v", legacy.Total)
+	}
+}
@@ -0,0 +1,112 @@
+package amazon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseSavedPages parses a directory of Amazon pages saved from a browser
+// (e.g. with "Save page as") after client-side decryption has run.
+//
+// Each .html/.htm file below dir is classified as an order list, order
+// details or transactions page by its content; other files are skipped.
+// Order summaries are merged with order details by order ID the same way
+// FetchOrders does, and orders that only have details are appended after.
+//
+// Prices and dates are read in the format of marketplace m; nil means amazon.com.
+func ParseSavedPages(dir string, m *Marketplace) ([]*Order, []*Transaction, error) {
+	parser := NewMarketplaceParser(m)
+
+	var summaries []*OrderSummary
+	var transactions []*Transaction
+	details := make(map[string]*Order)
+	var detailIDs []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isHTMLFile(path) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		pageType, err := parser.ClassifyPage(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to classify %s: %w", path, err)
+		}
+
+		switch pageType {
+		case PageTypeOrderList:
+			pageSummaries, err := parser.ParseOrderList(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("failed to parse order list %s: %w", path, err)
+			}
+			summaries = append(summaries, pageSummaries...)
+
+		case PageTypeOrderDetails:
+			order, err := parser.ParseOrderDetails(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("failed to parse order details %s: %w", path, err)
+			}
+			if order.ID == "" {
+				return nil
+			}
+			if _, seen := details[order.ID]; !seen {
+				detailIDs = append(detailIDs, order.ID)
+			}
+			details[order.ID] = order
+
+		case PageTypeTransactions:
+			pageTransactions, err := parser.ParseTransactions(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("failed to parse transactions %s: %w", path, err)
+			}
+			transactions = append(transactions, pageTransactions...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var orders []*Order
+	seen := make(map[string]bool)
+
+	for _, summary := range summaries {
+		if summary.ID == "" || seen[summary.ID] {
+			continue
+		}
+		seen[summary.ID] = true
+
+		if order, ok := details[summary.ID]; ok {
+			orders = append(orders, mergeOrderSummary(order, summary))
+		} else {
+			orders = append(orders, orderFromSummary(summary))
+		}
+	}
+
+	// Include orders that were saved without a matching order list page
+	for _, id := range detailIDs {
+		if !seen[id] {
+			seen[id] = true
+			orders = append(orders, details[id])
+		}
+	}
+
+	return orders, transactions, nil
+}
+
+// isHTMLFile reports whether path looks like a saved HTML page
+func isHTMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".html" || ext == ".htm"
+}
@@ -0,0 +1,144 @@
+package amazon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testOrderDetailsPage = `<html><body>
+<span>Order # 114-9733092-9360267</span>
+<div id="od-subtotals">
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Item(s) Subtotal:</span><span class="od-line-item-row-content">$41.98</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Estimated tax:</span><span class="od-line-item-row-content">$2.93</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Grand Total:</span><span class="od-line-item-row-content">$44.91</span></div>
+</div>
+<div data-component="shipments">
+	<a href="/dp/B09XV8WDY6">USB-C Charging Cable</a>
+	<div data-component="unitPrice"><span class="a-offscreen">$41.98</span></div>
+</div>
+</body></html>`
+
+const testTransactionsPage = `<html><body>
+<div class="apx-transactions-line-item-component-container">
+	<div data-pmts-component-id="row">
+		<div class="a-column a-span9"><span class="a-text-bold">Prime Visa ****1211</span></div>
+		<div class="a-column a-span3"><span class="a-text-bold">-$44.91</span></div>
+		<div class="a-column a-span12"><a href="/your-orders/order-details?orderID=114-9733092-9360267">Order #114-9733092-9360267</a></div>
+	</div>
+</div>
+</body></html>`
+
+const testOtherOrderDetailsPage = `<html><body>
+<span>Order # 113-7382612-3141857</span>
+<div id="od-subtotals">
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Grand Total:</span><span class="od-line-item-row-content">$12.00</span></div>
+</div>
+</body></html>`
+
+func TestClassifyPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected PageType
+	}{
+		{"order list", testOrderListPage, PageTypeOrderList},
+		{"order details", testOrderDetailsPage, PageTypeOrderDetails},
+		{"transactions", testTransactionsPage, PageTypeTransactions},
+		{"unknown", "<html><body>Hello</body></html>", ""},
+	}
+
+	parser := NewParser()
+	for _, tc := range tests {
+		result, err := parser.ClassifyPage(strings.NewReader(tc.html))
+		if err != nil {
+			t.Fatalf("ClassifyPage(%s) failed: %v", tc.name, err)
+		}
+		if result != tc.expected {
+			t.Errorf("ClassifyPage(%s) = %q, want %q", tc.name, result, tc.expected)
+		}
+	}
+}
+
+func TestParseSavedPages(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"Your Orders.html":                 testOrderListPage,
+		"Order Details.html":               testOrderDetailsPage,
+		"Order Details 2.htm":              testOtherOrderDetailsPage,
+		"Transactions.html":                testTransactionsPage,
+		"Your Orders_files/unrelated.html": "<html><body>ad frame</body></html>",
+		"Your Orders_files/styles.css":     "body {}",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	orders, transactions, err := ParseSavedPages(tmpDir, nil)
+	if err != nil {
+		t.Fatalf("ParseSavedPages failed: %v", err)
+	}
+
+	if len(orders) != 2 {
+		t.Fatalf("Expected 2 orders, got %d", len(orders))
+	}
+
+	// Summary merged with details keeps the list page's date and the details' items
+	order := orders[0]
+	if order.ID != "114-9733092-9360267" {
+		t.Errorf("Expected first order 114-9733092-9360267, got %s", order.ID)
+	}
+	if order.Date.IsZero() {
+		t.Error("Expected date to be filled in from order list")
+	}
+	if len(order.Items) != 1 || order.Items[0].ASIN != "B09XV8WDY6" {
+		t.Errorf("Expected item from order details, got %+v", order.Items)
+	}
+	if order.Tax.Decimal() != "2.93" {
+		t.Errorf("Expected tax 2.93, got %s", order.Tax)
+	}
+
+	// Details without a list page entry are still returned
+	if orders[1].ID != "113-7382612-3141857" || orders[1].Total.Decimal() != "12.00" {
+		t.Errorf("Unexpected details-only order: %+v", orders[1])
+	}
+
+	if len(transactions) != 1 || transactions[0].Amount.Decimal() != "44.91" {
+		t.Errorf("Expected 1 transaction of 44.91, got %+v", transactions)
+	}
+}
+
+func TestParseSavedPages_Marketplace(t *testing.T) {
+	tmpDir := t.TempDir()
+	html := `<html><body>
+<div class="order-card">
+	<ul><li class="order-header__header-list-item">Bestellung aufgegeben 26. November 2025</li>
+	<li class="order-header__header-list-item">Summe 1.044,91 €</li></ul>
+	<a href="/your-orders/order-details?orderID=302-9733092-9360267">Bestelldetails anzeigen</a>
+</div>
+</body></html>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "Meine Bestellungen.html"), []byte(html), 0600); err != nil {
+		t.Fatalf("failed to write page: %v", err)
+	}
+
+	orders, _, err := ParseSavedPages(tmpDir, MarketplaceDE)
+	if err != nil {
+		t.Fatalf("ParseSavedPages failed: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("Expected 1 order, got %d", len(orders))
+	}
+	if orders[0].Total != NewMoney(104491, "EUR") {
+		t.Errorf("Expected total 1044.91 EUR, got %s", orders[0].Total)
+	}
+	if got := orders[0].Date.Format("2006-01-02"); got != "2025-11-26" {
+		t.Errorf("Expected date 2025-11-26, got %s", got)
+	}
+}
@@ -2,9 +2,14 @@ package amazon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,15 +20,44 @@ type FetchOptions struct {
 	Year           int
 	MaxOrders      int
 	IncludeDetails bool
+	IncludeDigital bool // Also read the digital order list (Kindle, Prime Video, apps and music)
+
+	// TimeFilter reads a single order list filter, such as "last30",
+	// "months-3", "year-2024" or "archived", as listed by GetTimeFilters.
+	// It takes precedence over Year; StartDate and EndDate still filter the results.
+	TimeFilter string
+}
+
+// TimeFilter is an option of the order list's time filter dropdown
+type TimeFilter struct {
+	Value string `json:"value"`          // Query value, e.g. "last30", "months-3", "year-2024" or "archived"
+	Label string `json:"label"`          // As shown, e.g. "past 3 months"
+	Year  int    `json:"year,omitempty"` // Set for year filters only
+}
+
+// yearFilter returns the time filter value for a calendar year
+func yearFilter(year int) string {
+	return fmt.Sprintf("year-%d", year)
+}
+
+// filterYear returns the year of a year time filter like "year-2024", or 0
+func filterYear(filter string) int {
+	year, ok := strings.CutPrefix(filter, "year-")
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.Atoi(year)
+	return n
 }
 
 // FetchOrders fetches orders within the specified date range
 func (c *Client) FetchOrders(ctx context.Context, opts FetchOptions) ([]*Order, error) {
 	// Get order summaries first
-	summaries, err := c.fetchOrderSummaries(ctx, opts)
+	list, err := c.FetchOrderSummaries(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
+	summaries := list.Summaries
 
 	c.logger.Info("fetched order summaries", "count", len(summaries))
 
@@ -31,127 +65,241 @@ func (c *Client) FetchOrders(ctx context.Context, opts FetchOptions) ([]*Order,
 	if !opts.IncludeDetails {
 		orders := make([]*Order, len(summaries))
 		for i, summary := range summaries {
-			orders[i] = &Order{
-				ID:    summary.ID,
-				Date:  summary.Date,
-				Total: summary.Total,
-			}
+			orders[i] = orderFromSummary(summary)
 		}
 		return orders, nil
 	}
 
-	// Fetch full details for each order
-	var orders []*Order
-	parser := NewParser()
+	// Fetch full details for each order, keeping list order
+	orders := make([]*Order, len(summaries))
+	orderIDs := make([]string, len(summaries))
+	for i, summary := range summaries {
+		orderIDs[i] = summary.ID
+	}
+	parser := c.newParser()
+	var done atomic.Int64
 
-	for _, summary := range summaries {
-		select {
-		case <-ctx.Done():
-			return orders, ctx.Err()
-		default:
-		}
+	errs, err := c.forEach(ctx, len(summaries), func(ctx context.Context, i int) error {
+		summary := summaries[i]
 
 		c.logger.Debug("fetching order details", "orderID", summary.ID)
 
-		order, err := c.fetchOrderDetails(summary.ID, parser)
+		order, err := c.fetchOrderDetails(ctx, summary.ID, parser)
+		c.hooks.detail(DetailEvent{
+			PageType: PageTypeOrderDetails,
+			OrderID:  summary.ID,
+			Err:      err,
+			Done:     int(done.Add(1)),
+			Total:    len(summaries),
+		})
 		if err != nil {
 			c.logger.Warn("failed to fetch order details",
 				"orderID", summary.ID,
 				"error", err,
 			)
 			// Use summary data as fallback
-			orders = append(orders, &Order{
-				ID:    summary.ID,
-				Date:  summary.Date,
-				Total: summary.Total,
-			})
-			continue
+			orders[i] = orderFromSummary(summary)
+			return err
 		}
 
-		// Fill in date from summary if not parsed from details
-		if order.Date.IsZero() {
-			order.Date = summary.Date
+		orders[i] = mergeOrderSummary(order, summary)
+		return nil
+	})
+
+	// Drop orders that were never fetched because the batch stopped early
+	fetched := orders[:0]
+	for _, order := range orders {
+		if order != nil {
+			fetched = append(fetched, order)
 		}
+	}
+
+	if err != nil {
+		return fetched, err
+	}
+
+	return fetched, collectOrderErrors(orderIDs, errs)
+}
+
+// orderFromSummary builds an order from list page data when details are unavailable
+func orderFromSummary(summary *OrderSummary) *Order {
+	return &Order{
+		ID:    summary.ID,
+		Kind:  summary.Kind,
+		Date:  summary.Date,
+		Total: summary.Total,
+	}
+}
+
+// mergeOrderSummary fills in fields missing from an order's details using its summary
+func mergeOrderSummary(order *Order, summary *OrderSummary) *Order {
+	if order.ID == "" {
+		order.ID = summary.ID
+	}
 
-		orders = append(orders, order)
+	// Fill in date from summary if not parsed from details
+	if order.Date.IsZero() {
+		order.Date = summary.Date
+		order.anchorShipmentDates()
 	}
 
-	return orders, nil
+	return order
 }
 
 // FetchOrder fetches a single order by ID
 func (c *Client) FetchOrder(ctx context.Context, orderID string) (*Order, error) {
-	parser := NewParser()
-	return c.fetchOrderDetails(orderID, parser)
+	parser := c.newParser()
+	return c.fetchOrderDetails(ctx, orderID, parser)
 }
 
-// fetchOrderSummaries fetches order list pages and returns summaries
-func (c *Client) fetchOrderSummaries(ctx context.Context, opts FetchOptions) ([]*OrderSummary, error) {
-	parser := NewParser()
-	var allSummaries []*OrderSummary
+// OrderList is the result of reading the order list pages
+type OrderList struct {
+	Summaries []*OrderSummary
+	Totals    []OrderListTotal // One per time filter and order kind read
+	Skipped   int              // Lists that could not be read and were left out
+}
 
-	// Determine which years to fetch
-	years := c.determineYears(opts)
+// OrderListTotal compares the number of orders an order list reported with
+// the number collected from its pages
+type OrderListTotal struct {
+	Filter    string    `json:"filter"`
+	Kind      OrderKind `json:"kind"`
+	Reported  int       `json:"reported"`  // As shown, e.g. "12 orders placed in 2025"; 0 if not shown
+	Collected int       `json:"collected"` // Before StartDate and EndDate filtering
+}
 
-	for _, year := range years {
+// Complete reports whether every order the list reported was collected.
+// Lists that don't show a count are taken as complete.
+func (t OrderListTotal) Complete() bool {
+	return t.Collected >= t.Reported
+}
+
+// FetchOrderSummaries reads the order list pages without fetching order
+// details, along with how many orders each list reported
+func (c *Client) FetchOrderSummaries(ctx context.Context, opts FetchOptions) (*OrderList, error) {
+	parser := c.newParser()
+	list := &OrderList{}
+
+	// Determine which order list filters to read
+	filters, err := c.determineTimeFilters(ctx, opts)
+	if err != nil {
+		return list, err
+	}
+
+	kinds := orderKinds(opts)
+
+	for _, filter := range filters {
 		select {
 		case <-ctx.Done():
-			return allSummaries, ctx.Err()
+			return list, ctx.Err()
 		default:
 		}
 
-		summaries, err := c.fetchYearOrders(year, parser, opts)
-		if err != nil {
-			c.logger.Warn("failed to fetch orders for year",
-				"year", year,
-				"error", err,
-			)
-			continue
-		}
+		for _, kind := range kinds {
+			summaries, total, err := c.fetchFilterOrders(ctx, filter, kind, parser, opts)
+			if err != nil {
+				// Other filters will be blocked the same way, so give up early
+				if isBlockedPageError(err) {
+					return list, err
+				}
+				c.logger.Warn("failed to fetch orders",
+					"filter", filter,
+					"kind", kind,
+					"error", err,
+				)
+				list.Skipped++
+				continue
+			}
 
-		// Filter by date range if specified
-		for _, s := range summaries {
-			if c.isWithinDateRange(s.Date, opts) {
-				allSummaries = append(allSummaries, s)
+			list.Totals = append(list.Totals, total)
+			c.checkOrderListTotal(total, opts)
+
+			// Filter by date range if specified
+			for _, s := range summaries {
+				if c.isWithinDateRange(s.Date, opts) {
+					list.Summaries = append(list.Summaries, s)
+				}
 			}
 		}
 
 		// Check if we have enough orders
-		if opts.MaxOrders > 0 && len(allSummaries) >= opts.MaxOrders {
-			allSummaries = allSummaries[:opts.MaxOrders]
+		if opts.MaxOrders > 0 && len(list.Summaries) >= opts.MaxOrders {
+			list.Summaries = list.Summaries[:opts.MaxOrders]
 			break
 		}
 	}
 
-	return allSummaries, nil
+	return list, nil
 }
 
-// determineYears returns the list of years to fetch based on options
-func (c *Client) determineYears(opts FetchOptions) []int {
-	if opts.Year > 0 {
-		return []int{opts.Year}
+// orderKinds returns the order lists to read for each time filter
+func orderKinds(opts FetchOptions) []OrderKind {
+	kinds := []OrderKind{OrderKindPhysical}
+	if opts.IncludeDigital {
+		kinds = append(kinds, OrderKindDigital)
 	}
+	return kinds
+}
 
-	currentYear := time.Now().Year()
+// checkOrderListTotal logs an order list that had fewer orders than it
+// reported, unless MaxOrders stopped it early
+func (c *Client) checkOrderListTotal(total OrderListTotal, opts FetchOptions) {
+	if total.Complete() || (opts.MaxOrders > 0 && total.Collected >= opts.MaxOrders) {
+		return
+	}
+	c.logger.Warn("order list incomplete",
+		"filter", total.Filter,
+		"kind", total.Kind,
+		"reported", total.Reported,
+		"collected", total.Collected,
+	)
+}
 
-	// If date range is specified, calculate years in range
-	if !opts.StartDate.IsZero() && !opts.EndDate.IsZero() {
-		startYear := opts.StartDate.Year()
-		endYear := opts.EndDate.Year()
+// determineTimeFilters returns the order list filters to read. Without an
+// explicit filter, year or complete date range it reads the year dropdown and
+// picks the years that overlap StartDate and EndDate.
+func (c *Client) determineTimeFilters(ctx context.Context, opts FetchOptions) ([]string, error) {
+	switch {
+	case opts.TimeFilter != "":
+		return []string{opts.TimeFilter}, nil
+	case opts.Year > 0:
+		return []string{yearFilter(opts.Year)}, nil
+	case !opts.StartDate.IsZero() && !opts.EndDate.IsZero():
+		var filters []string
+		for y := opts.EndDate.Year(); y >= opts.StartDate.Year(); y-- {
+			filters = append(filters, yearFilter(y))
+		}
+		return filters, nil
+	}
 
-		var years []int
-		for y := endYear; y >= startYear; y-- {
-			years = append(years, y)
+	years, err := c.GetOrderYears(ctx)
+	if err != nil {
+		if isBlockedPageError(err) {
+			return nil, err
 		}
-		return years
+		c.logger.Warn("failed to read order years", "error", err)
 	}
 
-	// Default: current year only
-	if !opts.EndDate.IsZero() {
-		return []int{opts.EndDate.Year()}
+	var filters []string
+	for _, year := range years {
+		if !opts.StartDate.IsZero() && year < opts.StartDate.Year() {
+			continue
+		}
+		if !opts.EndDate.IsZero() && year > opts.EndDate.Year() {
+			continue
+		}
+		filters = append(filters, yearFilter(year))
 	}
 
-	return []int{currentYear}
+	// Without a dropdown, fall back to the year the range ends in
+	if len(filters) == 0 {
+		end := opts.EndDate
+		if end.IsZero() {
+			end = time.Now()
+		}
+		filters = []string{yearFilter(end.Year())}
+	}
+	return filters, nil
 }
 
 // isWithinDateRange checks if a date is within the specified range
@@ -171,62 +319,166 @@ func (c *Client) isWithinDateRange(date time.Time, opts FetchOptions) bool {
 	return true
 }
 
-// fetchYearOrders fetches all orders for a specific year
-func (c *Client) fetchYearOrders(year int, parser *Parser, opts FetchOptions) ([]*OrderSummary, error) {
-	var allSummaries []*OrderSummary
-	startIndex := 0
-	pageSize := 10 // Amazon typically shows 10 orders per page
-
-	for {
-		// Build URL with pagination
-		orderURL := c.buildOrderListURL(year, startIndex)
-
-		c.logger.Debug("fetching order list page",
-			"year", year,
-			"startIndex", startIndex,
-			"url", orderURL,
-		)
-
-		resp, err := c.get(orderURL)
-		if err != nil {
-			return allSummaries, fmt.Errorf("failed to fetch orders: %w", err)
-		}
+// defaultOrderPageSize is how many orders Amazon shows per page. It is only
+// relied on for pages with neither a pagination control nor an order count.
+const defaultOrderPageSize = 10
 
-		summaries, err := parser.ParseOrderList(resp.Body)
-		resp.Body.Close()
+// fetchFilterOrders fetches all orders of one kind for an order list time
+// filter, following the list's own pagination
+func (c *Client) fetchFilterOrders(ctx context.Context, filter string, kind OrderKind, parser *Parser, opts FetchOptions) ([]*OrderSummary, OrderListTotal, error) {
+	cursor := c.newOrderListCursor(filter, kind, parser)
+	var allSummaries []*OrderSummary
 
+	for !cursor.done() {
+		summaries, err := cursor.next(ctx)
 		if err != nil {
-			return allSummaries, fmt.Errorf("failed to parse order list: %w", err)
+			return allSummaries, cursor.total, err
 		}
-
-		// No more orders found
-		if len(summaries) == 0 {
-			break
-		}
-
 		allSummaries = append(allSummaries, summaries...)
 
 		// Check if we've hit the limit
 		if opts.MaxOrders > 0 && len(allSummaries) >= opts.MaxOrders {
 			break
 		}
+	}
 
-		// Check if we got a full page (more pages likely available)
-		if len(summaries) < pageSize {
-			break
+	return allSummaries, cursor.total, nil
+}
+
+// orderListCursor walks the pages of one order list, one page at a time
+type orderListCursor struct {
+	client     *Client
+	parser     *Parser
+	filter     string
+	kind       OrderKind
+	url        string // Next page to fetch; empty after the last page
+	startIndex int
+	page       int // Pages fetched so far
+	visited    map[string]bool
+	seen       map[string]bool
+	total      OrderListTotal
+}
+
+// newOrderListCursor starts at the first page of an order list
+func (c *Client) newOrderListCursor(filter string, kind OrderKind, parser *Parser) *orderListCursor {
+	return &orderListCursor{
+		client:  c,
+		parser:  parser,
+		filter:  filter,
+		kind:    kind,
+		url:     c.buildOrderListURL(filter, 0, kind),
+		visited: make(map[string]bool),
+		seen:    make(map[string]bool),
+		total:   OrderListTotal{Filter: filter, Kind: kind},
+	}
+}
+
+// done reports whether every page has been fetched
+func (lc *orderListCursor) done() bool {
+	return lc.url == "" || lc.visited[lc.url]
+}
+
+// next fetches the next page and returns the orders on it not seen on earlier pages
+func (lc *orderListCursor) next(ctx context.Context) ([]*OrderSummary, error) {
+	orderURL := lc.url
+	lc.visited[orderURL] = true
+	lc.url = ""
+	lc.page++
+
+	lc.client.logger.Debug("fetching order list page",
+		"filter", lc.filter,
+		"kind", lc.kind,
+		"startIndex", lc.startIndex,
+		"url", orderURL,
+	)
+
+	body, err := lc.client.pageSource.FetchPage(ctx, orderURL)
+	if err != nil {
+		// Saved page sets end where the pages run out
+		if lc.startIndex > 0 && errors.Is(err, ErrPageNotFound) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to fetch orders: %w", err)
+	}
+
+	page, err := lc.parser.ParseOrderListPage(body)
+	body.Close()
 
-		startIndex += pageSize
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse order list: %w", withPageURL(err, orderURL))
 	}
 
-	return allSummaries, nil
+	if page.TotalOrders > 0 {
+		lc.total.Reported = page.TotalOrders
+	}
+	var summaries []*OrderSummary
+	for _, summary := range page.Orders {
+		// Pages can overlap if the list changes while it is read
+		if summary.ID != "" && lc.seen[summary.ID] {
+			continue
+		}
+		lc.seen[summary.ID] = true
+		summaries = append(summaries, summary)
+	}
+	lc.total.Collected += len(summaries)
+
+	lc.client.hooks.pageFetched(PageFetchedEvent{
+		URL:         orderURL,
+		Filter:      lc.filter,
+		Year:        filterYear(lc.filter),
+		Kind:        lc.kind,
+		Page:        lc.page,
+		Orders:      len(summaries),
+		TotalOrders: page.TotalOrders,
+	})
+	for _, summary := range summaries {
+		lc.client.hooks.summaryParsed(SummaryParsedEvent{Summary: summary, Filter: lc.filter, Kind: lc.kind, Page: lc.page})
+	}
+
+	// No more orders found
+	if page.Cards == 0 {
+		return summaries, nil
+	}
+
+	lc.startIndex += page.Cards
+	lc.url = lc.client.nextOrderListURL(page, lc.filter, lc.startIndex, lc.kind, lc.total.Reported)
+	return summaries, nil
+}
+
+// nextOrderListURL returns the URL of the order list page after page, or ""
+// if it was the last. The pagination control decides when the page has one;
+// otherwise paging continues until the reported number of orders has been
+// read, or, without a count, until a page is not full.
+func (c *Client) nextOrderListURL(page *OrderListPage, filter string, startIndex int, kind OrderKind, reported int) string {
+	switch {
+	case page.NextURL != "":
+		u, err := url.Parse(page.NextURL)
+		if err != nil {
+			return ""
+		}
+		// Stay on the client's base URL, whatever host the link names
+		return c.pageURL(u.RequestURI())
+	case page.HasPagination:
+		return ""
+	case reported > 0:
+		if startIndex >= reported {
+			return ""
+		}
+	case page.Cards < defaultOrderPageSize:
+		return ""
+	}
+	return c.buildOrderListURL(filter, startIndex, kind)
 }
 
 // buildOrderListURL builds the URL for the order list page
-func (c *Client) buildOrderListURL(year int, startIndex int) string {
-	u, _ := url.Parse(ordersURL)
+func (c *Client) buildOrderListURL(filter string, startIndex int, kind OrderKind) string {
+	u, _ := url.Parse(c.pageURL(ordersPath))
 	q := u.Query()
-	q.Set("timeFilter", fmt.Sprintf("year-%d", year))
+	q.Set("timeFilter", filter)
+	if kind == OrderKindDigital {
+		q.Set("digitalOrders", "1")
+		q.Set("unifiedOrders", "0")
+	}
 	if startIndex > 0 {
 		q.Set("startIndex", strconv.Itoa(startIndex))
 	}
@@ -235,21 +487,26 @@ func (c *Client) buildOrderListURL(year int, startIndex int) string {
 }
 
 // fetchOrderDetails fetches and parses a single order's details
-func (c *Client) fetchOrderDetails(orderID string, parser *Parser) (*Order, error) {
-	u, _ := url.Parse(orderDetailsURL)
+func (c *Client) fetchOrderDetails(ctx context.Context, orderID string, parser *Parser) (*Order, error) {
+	path := orderDetailsPath
+	if isDigitalOrderID(orderID) {
+		path = digitalOrderDetailsPath
+	}
+
+	u, _ := url.Parse(c.pageURL(path))
 	q := u.Query()
 	q.Set("orderID", orderID)
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u.String())
+	body, err := c.pageSource.FetchPage(ctx, u.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch order details: %w", err)
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	order, err := parser.ParseOrderDetails(resp.Body)
+	order, err := parser.ParseOrderDetails(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse order details: %w", err)
+		return nil, fmt.Errorf("failed to parse order details: %w", withPageURL(err, u.String()))
 	}
 
 	// Ensure order ID is set
@@ -257,51 +514,74 @@ func (c *Client) fetchOrderDetails(orderID string, parser *Parser) (*Order, erro
 		order.ID = orderID
 	}
 
+	// Report charge summaries that don't add up, e.g. an unrecognized discount row
+	if err := order.VerifyTotals(); err != nil {
+		c.logger.Warn("order charges do not match grand total",
+			"order_id", order.ID,
+			"error", err,
+		)
+	}
+
 	return order, nil
 }
 
-// GetOrderYears returns the list of years that have orders
-func (c *Client) GetOrderYears(ctx context.Context) ([]int, error) {
-	// Fetch the order list page and parse available year filters
-	resp, err := c.get(ordersURL)
+// GetTimeFilters reads the order list's time filter dropdown: recent
+// periods, each year with orders and archived orders
+func (c *Client) GetTimeFilters(ctx context.Context) ([]TimeFilter, error) {
+	pageURL := c.pageURL(ordersPath)
+
+	body, err := c.pageSource.FetchPage(ctx, pageURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch orders page: %w", err)
 	}
-	defer resp.Body.Close()
+	defer body.Close()
+
+	filters, err := c.newParser().ParseTimeFilters(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse time filters: %w", withPageURL(err, pageURL))
+	}
+	return filters, nil
+}
 
-	// For now, return reasonable defaults
-	// Could enhance to parse the year dropdown from the page
-	currentYear := time.Now().Year()
-	years := make([]int, 0, 5)
-	for y := currentYear; y >= currentYear-4; y-- {
-		years = append(years, y)
+// GetOrderYears returns the years that have orders, newest first
+func (c *Client) GetOrderYears(ctx context.Context) ([]int, error) {
+	filters, err := c.GetTimeFilters(ctx)
+	if err != nil {
+		return nil, err
 	}
 
+	var years []int
+	for _, filter := range filters {
+		if filter.Year > 0 {
+			years = append(years, filter.Year)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
 	return years, nil
 }
 
 // FetchTransactions fetches payment transactions for an order
 // This returns the actual charges made to payment methods
 func (c *Client) FetchTransactions(ctx context.Context, orderID string) ([]*Transaction, error) {
-	parser := NewParser()
+	parser := c.newParser()
 
 	// Build transactions URL
-	u, _ := url.Parse(transactionsURL)
+	u, _ := url.Parse(c.pageURL(transactionsPath))
 	q := u.Query()
 	q.Set("transactionTag", orderID)
 	u.RawQuery = q.Encode()
 
 	c.logger.Debug("fetching transactions", "orderID", orderID, "url", u.String())
 
-	resp, err := c.get(u.String())
+	body, err := c.pageSource.FetchPage(ctx, u.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	transactions, err := parser.ParseTransactions(resp.Body)
+	transactions, err := parser.ParseTransactions(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse transactions: %w", err)
+		return nil, fmt.Errorf("failed to parse transactions: %w", withPageURL(err, u.String()))
 	}
 
 	// Ensure order ID is set on all transactions
@@ -316,12 +596,61 @@ func (c *Client) FetchTransactions(ctx context.Context, orderID string) ([]*Tran
 	return transactions, nil
 }
 
+// FetchTracking fetches a shipment's tracking page and sets its Tracking details.
+// The request goes to the client's base URL, whatever host TrackingURL names.
+func (c *Client) FetchTracking(ctx context.Context, shipment *Shipment) (*Tracking, error) {
+	if shipment == nil || shipment.TrackingURL == "" {
+		return nil, fmt.Errorf("shipment has no tracking link")
+	}
+
+	u, err := url.Parse(shipment.TrackingURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracking URL: %w", err)
+	}
+	pageURL := c.pageURL(u.RequestURI())
+
+	body, err := c.pageSource.FetchPage(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tracking: %w", err)
+	}
+	defer body.Close()
+
+	tracking, err := c.newParser().ParseTracking(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tracking: %w", withPageURL(err, pageURL))
+	}
+
+	shipment.Tracking = tracking
+	return tracking, nil
+}
+
+// FetchRefunds fetches the returns center and returns one refund per return,
+// including returns whose refund hasn't been issued yet
+func (c *Client) FetchRefunds(ctx context.Context) ([]*Refund, error) {
+	pageURL := c.pageURL(returnsPath)
+
+	body, err := c.pageSource.FetchPage(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch returns: %w", err)
+	}
+	defer body.Close()
+
+	refunds, err := c.newParser().ParseReturns(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse returns: %w", withPageURL(err, pageURL))
+	}
+
+	c.logger.Debug("parsed returns", "count", len(refunds))
+
+	return refunds, nil
+}
+
 // FetchOrderWithTransactions fetches an order with its payment transactions
 func (c *Client) FetchOrderWithTransactions(ctx context.Context, orderID string) (*Order, []*Transaction, error) {
-	parser := NewParser()
+	parser := c.newParser()
 
 	// Fetch order details
-	order, err := c.fetchOrderDetails(orderID, parser)
+	order, err := c.fetchOrderDetails(ctx, orderID, parser)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to fetch order: %w", err)
 	}
@@ -334,31 +663,43 @@ func (c *Client) FetchOrderWithTransactions(ctx context.Context, orderID string)
 		return order, nil, nil
 	}
 
-	return order, transactions, nil
+	return order, withRefundTransactions(transactions, order.Refunds), nil
 }
 
-// FetchAllTransactions fetches transactions for multiple orders
+// FetchAllTransactions fetches transactions for multiple orders.
+// Orders whose transactions could not be fetched are reported in an OrderErrors.
 func (c *Client) FetchAllTransactions(ctx context.Context, orderIDs []string) (map[string][]*Transaction, error) {
 	result := make(map[string][]*Transaction)
+	var mu sync.Mutex
+	var done atomic.Int64
 
-	for _, orderID := range orderIDs {
-		select {
-		case <-ctx.Done():
-			return result, ctx.Err()
-		default:
-		}
+	errs, err := c.forEach(ctx, len(orderIDs), func(ctx context.Context, i int) error {
+		orderID := orderIDs[i]
 
 		transactions, err := c.FetchTransactions(ctx, orderID)
+		c.hooks.detail(DetailEvent{
+			PageType: PageTypeTransactions,
+			OrderID:  orderID,
+			Err:      err,
+			Done:     int(done.Add(1)),
+			Total:    len(orderIDs),
+		})
 		if err != nil {
 			c.logger.Warn("failed to fetch transactions for order",
 				"orderID", orderID,
 				"error", err,
 			)
-			continue
+			return err
 		}
 
+		mu.Lock()
 		result[orderID] = transactions
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return result, err
 	}
 
-	return result, nil
+	return result, collectOrderErrors(orderIDs, errs)
 }
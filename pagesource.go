@@ -0,0 +1,242 @@
+package amazon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrPageNotFound is returned by a PageSource that has no copy of the requested page
+var ErrPageNotFound = errors.New("page not found")
+
+// PageSource fetches the HTML of an Amazon page by URL.
+// The caller must close the returned reader.
+type PageSource interface {
+	FetchPage(ctx context.Context, pageURL string) (io.ReadCloser, error)
+}
+
+// httpPageSource fetches pages live from Amazon using the client's cookies,
+// rate limiting and retry logic
+type httpPageSource struct {
+	client *Client
+}
+
+// FetchPage implements PageSource
+func (s *httpPageSource) FetchPage(ctx context.Context, pageURL string) (io.ReadCloser, error) {
+	resp, err := s.client.get(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// An error page would otherwise parse as an empty order list or order
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			return nil, fmt.Errorf("%w: %s (status %d)", ErrPageNotFound, pageURL, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("unexpected status %d for %s", resp.StatusCode, pageURL)
+	}
+
+	return resp.Body, nil
+}
+
+// DirPageSource serves pages from a directory of saved HTML files.
+// Files are looked up by the name returned from PageFileName.
+type DirPageSource struct {
+	dir string
+}
+
+// NewDirPageSource creates a page source that reads saved pages from dir
+func NewDirPageSource(dir string) (*DirPageSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open page directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+	return &DirPageSource{dir: dir}, nil
+}
+
+// FetchPage implements PageSource
+func (s *DirPageSource) FetchPage(ctx context.Context, pageURL string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(s.dir, PageFileName(pageURL))
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrPageNotFound, pageURL)
+		}
+		return nil, fmt.Errorf("failed to open saved page: %w", err)
+	}
+	return f, nil
+}
+
+// unsafeFileChars matches characters that are replaced when mapping URLs to file names
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// PageFileName returns the file name DirPageSource uses for a page URL.
+// The host is ignored and query parameters are sorted, so equivalent URLs
+// map to the same file.
+// Example: PageFileName("https://www.amazon.com/your-orders/orders?timeFilter=year-2025")
+// -> "your-orders_orders_timeFilter_year-2025.html"
+func PageFileName(pageURL string) string {
+	key := pageURL
+	if u, err := url.Parse(pageURL); err == nil {
+		key = strings.Trim(u.Path, "/")
+		if q := u.Query().Encode(); q != "" {
+			key += "?" + q
+		}
+	}
+	return strings.Trim(unsafeFileChars.ReplaceAllString(key, "_"), "_") + ".html"
+}
+
+// commandPageRequest is written to a command page source's stdin, one per line
+type commandPageRequest struct {
+	URL string `json:"url"`
+}
+
+// commandPageResponse is read from a command page source's stdout, one per line
+type commandPageResponse struct {
+	URL   string `json:"url"`
+	HTML  string `json:"html"`
+	Error string `json:"error,omitempty"`
+}
+
+// CommandPageSource fetches pages from an external process, such as a
+// headless-browser bridge that renders and decrypts pages.
+//
+// The process is started on first use. For each page a JSON object
+// {"url": "..."} is written to its stdin on a single line, and it must reply
+// on stdout with a single-line JSON object {"url": "...", "html": "..."}, or
+// {"url": "...", "error": "..."} if the page could not be rendered.
+type CommandPageSource struct {
+	name string
+	args []string
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	encoder *json.Encoder
+	decoder *json.Decoder
+}
+
+// NewCommandPageSource creates a page source backed by the given command
+func NewCommandPageSource(name string, args ...string) *CommandPageSource {
+	return &CommandPageSource{
+		name: name,
+		args: args,
+	}
+}
+
+// FetchPage implements PageSource
+func (s *CommandPageSource) FetchPage(ctx context.Context, pageURL string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.start(); err != nil {
+		return nil, err
+	}
+
+	if err := s.encoder.Encode(commandPageRequest{URL: pageURL}); err != nil {
+		s.stop()
+		return nil, fmt.Errorf("failed to send request to page command: %w", err)
+	}
+
+	// Read the reply in the background so a hung process can be abandoned
+	type result struct {
+		resp commandPageResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var resp commandPageResponse
+		err := s.decoder.Decode(&resp)
+		done <- result{resp, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.stop()
+		return nil, ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			s.stop()
+			return nil, fmt.Errorf("failed to read response from page command: %w", res.err)
+		}
+		if res.resp.Error != "" {
+			return nil, fmt.Errorf("page command failed for %s: %s", pageURL, res.resp.Error)
+		}
+		return io.NopCloser(strings.NewReader(res.resp.HTML)), nil
+	}
+}
+
+// Close stops the external process if it is running
+func (s *CommandPageSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stop()
+}
+
+// start launches the external process if it is not already running
+func (s *CommandPageSource) start() error {
+	if s.cmd != nil {
+		return nil
+	}
+
+	cmd := exec.Command(s.name, s.args...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start page command: %w", err)
+	}
+
+	s.cmd = cmd
+	s.encoder = json.NewEncoder(stdin)
+	s.decoder = json.NewDecoder(stdout)
+	return nil
+}
+
+// stop kills the external process and resets state so the next fetch restarts it
+func (s *CommandPageSource) stop() error {
+	if s.cmd == nil {
+		return nil
+	}
+
+	cmd := s.cmd
+	s.cmd = nil
+	s.encoder = nil
+	s.decoder = nil
+
+	if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("failed to stop page command: %w", err)
+	}
+	cmd.Wait()
+	return nil
+}
@@ -0,0 +1,200 @@
+package amazon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testOrderListPage = `<html><body>
+<div class="order-card">
+	<ul><li class="order-header__header-list-item">Order placed November 26, 2025</li>
+	<li class="order-header__header-list-item">Total $44.91</li></ul>
+	<a href="/your-orders/order-details?orderID=114-9733092-9360267">View order details</a>
+</div>
+</body></html>`
+
+func TestPageFileName(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://www.amazon.com/your-orders/orders?timeFilter=year-2025",
+			expected: "your-orders_orders_timeFilter_year-2025.html",
+		},
+		{
+			url:      "https://www.amazon.com/your-orders/orders?timeFilter=year-2025&startIndex=10",
+			expected: "your-orders_orders_startIndex_10_timeFilter_year-2025.html",
+		},
+		{
+			url:      "https://www.amazon.com/your-orders/order-details?orderID=114-9733092-9360267",
+			expected: "your-orders_order-details_orderID_114-9733092-9360267.html",
+		},
+	}
+
+	for _, tc := range tests {
+		result := PageFileName(tc.url)
+		if result != tc.expected {
+			t.Errorf("PageFileName(%q) = %q, want %q", tc.url, result, tc.expected)
+		}
+	}
+}
+
+func TestDirPageSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	pageURL := "https://www.amazon.com/your-orders/orders?timeFilter=year-2025"
+	if err := os.WriteFile(filepath.Join(tmpDir, PageFileName(pageURL)), []byte(testOrderListPage), 0600); err != nil {
+		t.Fatalf("failed to write page: %v", err)
+	}
+
+	src, err := NewDirPageSource(tmpDir)
+	if err != nil {
+		t.Fatalf("NewDirPageSource failed: %v", err)
+	}
+
+	body, err := src.FetchPage(context.Background(), pageURL)
+	if err != nil {
+		t.Fatalf("FetchPage failed: %v", err)
+	}
+	body.Close()
+
+	_, err = src.FetchPage(context.Background(), pageURL+"&startIndex=10")
+	if !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("Expected ErrPageNotFound, got %v", err)
+	}
+}
+
+func TestFetchOrdersFromDirPageSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	pageURL := "https://www.amazon.com/your-orders/orders?timeFilter=year-2025"
+	if err := os.WriteFile(filepath.Join(tmpDir, PageFileName(pageURL)), []byte(testOrderListPage), 0600); err != nil {
+		t.Fatalf("failed to write page: %v", err)
+	}
+
+	src, err := NewDirPageSource(tmpDir)
+	if err != nil {
+		t.Fatalf("NewDirPageSource failed: %v", err)
+	}
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithPageSource(src),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	orders, err := client.FetchOrders(context.Background(), FetchOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("FetchOrders failed: %v", err)
+	}
+
+	if len(orders) != 1 || orders[0].ID != "114-9733092-9360267" {
+		t.Errorf("Expected order 114-9733092-9360267, got %+v", orders)
+	}
+}
+
+// TestHelperPageCommand is not a real test; it is run as a subprocess by
+// TestCommandPageSource to act as a page rendering bridge
+func TestHelperPageCommand(t *testing.T) {
+	if os.Getenv("AMAZON_GO_HELPER_PAGE_COMMAND") != "1" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+	for scanner.Scan() {
+		var req commandPageRequest
+		json.Unmarshal(scanner.Bytes(), &req)
+		if req.URL == "fail" {
+			encoder.Encode(commandPageResponse{URL: req.URL, Error: "render failed"})
+			continue
+		}
+		encoder.Encode(commandPageResponse{URL: req.URL, HTML: fmt.Sprintf("<p>%s</p>", req.URL)})
+	}
+	os.Exit(0)
+}
+
+func TestCommandPageSource(t *testing.T) {
+	t.Setenv("AMAZON_GO_HELPER_PAGE_COMMAND", "1")
+
+	src := NewCommandPageSource(os.Args[0], "-test.run=TestHelperPageCommand")
+	defer src.Close()
+
+	for _, pageURL := range []string{"https://www.amazon.com/a", "https://www.amazon.com/b"} {
+		body, err := src.FetchPage(context.Background(), pageURL)
+		if err != nil {
+			t.Fatalf("FetchPage(%q) failed: %v", pageURL, err)
+		}
+		data, _ := io.ReadAll(body)
+		body.Close()
+
+		if string(data) != fmt.Sprintf("<p>%s</p>", pageURL) {
+			t.Errorf("Unexpected page content: %s", data)
+		}
+	}
+
+	if _, err := src.FetchPage(context.Background(), "fail"); err == nil {
+		t.Error("Expected error for failed render")
+	}
+}
+
+func TestHTTPPageSource_CancelInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newRetryTestClient(t, DefaultRetryPolicy(3))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.pageSource.FetchPage(ctx, server.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to abort the request, took %v", elapsed)
+	}
+}
+
+func TestHTTPPageSource_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<html><body>Forbidden</body></html>")
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, DefaultRetryPolicy(3))
+
+	_, err := client.pageSource.FetchPage(context.Background(), server.URL+"/missing")
+	if !errors.Is(err, ErrPageNotFound) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected ErrPageNotFound with status 404, got %v", err)
+	}
+
+	_, err = client.pageSource.FetchPage(context.Background(), server.URL+"/forbidden")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected an error with status 403, got %v", err)
+	}
+}
@@ -12,21 +12,94 @@ import (
 )
 
 // Parser handles HTML parsing for Amazon order pages
-type Parser struct{}
+type Parser struct {
+	marketplace *Marketplace
+	baseURL     string           // Site links found on pages are made absolute against
+	now         func() time.Time // Reference for relative dates like "Arriving tomorrow"
+}
 
-// NewParser creates a new parser instance
+// NewParser creates a new parser instance for amazon.com pages
 func NewParser() *Parser {
-	return &Parser{}
+	return NewMarketplaceParser(MarketplaceUS)
+}
+
+// pageURL makes a link found on a page absolute against the parser's base URL
+func (p *Parser) pageURL(href string) string {
+	if strings.HasPrefix(href, "/") {
+		return p.baseURL + href
+	}
+	return href
+}
+
+// NewMarketplaceParser creates a parser for pages from the given marketplace,
+// reading prices and dates in its local format
+func NewMarketplaceParser(m *Marketplace) *Parser {
+	if m == nil {
+		m = MarketplaceUS
+	}
+	return &Parser{marketplace: m, baseURL: m.BaseURL(), now: time.Now}
 }
 
+// ClassifyPage inspects a page's content and reports which kind of Amazon
+// page it is. It returns an empty PageType if the page is not recognized.
+func (p *Parser) ClassifyPage(r io.Reader) (PageType, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	switch {
+	case doc.Find(".apx-transactions-line-item-component-container, .apx-transaction-date-container").Length() > 0,
+		doc.Find("h3:contains('Transactions from Order')").Length() > 0:
+		return PageTypeTransactions, nil
+	case doc.Find("#od-subtotals, [data-component='chargeSummary'], [data-component='shipments'], [data-component='shipmentsLeftGrid']").Length() > 0:
+		return PageTypeOrderDetails, nil
+	case doc.Find(".order-card").Length() > 0:
+		return PageTypeOrderList, nil
+	case isInvoicePage(doc):
+		return PageTypeInvoice, nil
+	case isDigitalOrderPage(doc):
+		return PageTypeOrderDetails, nil
+	}
+
+	return "", nil
+}
+
+// OrderListPage is one page of the order list with its pagination
+type OrderListPage struct {
+	Orders        []*OrderSummary
+	Cards         int    // Order cards on the page, including any that could not be parsed
+	TotalOrders   int    // Orders the list reports for its time filter, e.g. "12 orders placed in 2025"; 0 if not shown
+	NextURL       string // Link to the next page, as found on the page; empty on the last page
+	HasPagination bool   // Whether the page has a pagination control; without one NextURL says nothing
+}
+
+var totalOrdersPattern = regexp.MustCompile(`(\d[\d,.]*)\s+orders?\b`)
+
 // ParseOrderList parses the order list page and returns order summaries
 func (p *Parser) ParseOrderList(r io.Reader) ([]*OrderSummary, error) {
+	page, err := p.ParseOrderListPage(r)
+	if err != nil {
+		return nil, err
+	}
+	return page.Orders, nil
+}
+
+// ParseOrderListPage parses the order list page with its pagination control
+// and the total number of orders it reports
+func (p *Parser) ParseOrderListPage(r io.Reader) (*OrderListPage, error) {
 	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeOrderList, Err: err}
+	}
+
+	page := &OrderListPage{}
 	var orders []*OrderSummary
+	encrypted := 0
 
 	// Find all order cards
 	doc.Find(".order-card").Each(func(i int, s *goquery.Selection) {
@@ -35,10 +108,74 @@ func (p *Parser) ParseOrderList(r io.Reader) ([]*OrderSummary, error) {
 			// Log error but continue parsing other orders
 			return
 		}
+		// Encrypted cards have no readable order ID
+		if order.ID == "" && isEncrypted(s) {
+			encrypted++
+			return
+		}
 		orders = append(orders, order)
 	})
 
-	return orders, nil
+	if len(orders) == 0 && encrypted > 0 {
+		return nil, &PageError{PageType: PageTypeOrderList, Err: ErrEncryptedContent}
+	}
+
+	page.Orders = orders
+	page.Cards = doc.Find(".order-card").Length()
+	page.TotalOrders = parseTotalOrders(doc)
+
+	pagination := doc.Find("ul.a-pagination, .a-pagination").First()
+	page.HasPagination = pagination.Length() > 0
+	if next := pagination.Find("li.a-last").First(); !next.HasClass("a-disabled") {
+		page.NextURL = next.Find("a[href]").AttrOr("href", "")
+	}
+
+	return page, nil
+}
+
+// parseTotalOrders reads the order count shown above the order list, e.g.
+// "12 orders placed in 2025"
+func parseTotalOrders(doc *goquery.Document) int {
+	text := doc.Find(".num-orders").First().Text()
+	if text == "" {
+		text = doc.Find("label[for='time-filter'], .num-orders-for-orders-by-date").First().Text()
+	}
+	m := totalOrdersPattern.FindStringSubmatch(text)
+	if m == nil {
+		return 0
+	}
+	total, _ := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(m[1]))
+	return total
+}
+
+// ParseTimeFilters parses the time filter dropdown on the order list page
+func (p *Parser) ParseTimeFilters(r io.Reader) ([]TimeFilter, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeOrderList, Err: err}
+	}
+
+	var filters []TimeFilter
+	seen := make(map[string]bool)
+	doc.Find("select[name='timeFilter'] option, #time-filter option").Each(func(i int, option *goquery.Selection) {
+		value := strings.TrimSpace(option.AttrOr("value", ""))
+		if value == "" || seen[value] {
+			return
+		}
+		seen[value] = true
+
+		filters = append(filters, TimeFilter{
+			Value: value,
+			Label: strings.Join(strings.Fields(option.Text()), " "),
+			Year:  filterYear(value),
+		})
+	})
+
+	return filters, nil
 }
 
 // parseOrderCard extracts order summary from an order card element
@@ -47,9 +184,9 @@ func (p *Parser) parseOrderCard(s *goquery.Selection) (*OrderSummary, error) {
 
 	// Extract order ID from the order-id div or link
 	// Look for order details link which contains the order ID
-	detailLink := s.Find("a[href*='order-details']").First()
+	detailLink := s.Find("a[href*='order-details'], a[href*='order-summary']").First()
 	if href, exists := detailLink.Attr("href"); exists {
-		order.DetailURL = "https://www.amazon.com" + href
+		order.DetailURL = p.pageURL(href)
 		// Extract order ID from URL
 		if id := extractOrderIDFromURL(href); id != "" {
 			order.ID = id
@@ -65,15 +202,14 @@ func (p *Parser) parseOrderCard(s *goquery.Selection) (*OrderSummary, error) {
 		}
 	}
 
+	order.Kind = kindForOrderID(order.ID)
+
 	// Extract date from order header
 	// Look for the date text in the header list
 	s.Find(".order-header__header-list-item").Each(func(i int, item *goquery.Selection) {
 		text := strings.TrimSpace(item.Text())
-		// Check if this looks like a date (contains month names)
-		if containsMonth(text) {
-			if date, err := parseAmazonDate(text); err == nil {
-				order.Date = date
-			}
+		if date, err := p.marketplace.ParseDate(text); err == nil {
+			order.Date = date
 		}
 	})
 
@@ -81,8 +217,8 @@ func (p *Parser) parseOrderCard(s *goquery.Selection) (*OrderSummary, error) {
 	// Look for "Order Total" or total amount in header
 	s.Find(".order-header__header-list-item").Each(func(i int, item *goquery.Selection) {
 		text := strings.TrimSpace(item.Text())
-		if strings.Contains(strings.ToLower(text), "total") || strings.HasPrefix(text, "$") {
-			if price := parsePrice(text); price > 0 {
+		if strings.Contains(strings.ToLower(text), "total") || p.marketplace.hasCurrency(text) {
+			if price := p.marketplace.ParsePrice(text); price.Units > 0 {
 				order.Total = price
 			}
 		}
@@ -119,6 +255,10 @@ func (p *Parser) ParseOrderDetails(r io.Reader) (*Order, error) {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeOrderDetails, Err: err}
+	}
+
 	order := &Order{}
 
 	// Extract order ID from the page
@@ -141,12 +281,30 @@ func (p *Parser) ParseOrderDetails(r io.Reader) (*Order, error) {
 		})
 	}
 
+	// Grocery and digital orders have their own layouts
+	if isGroceryOrderPage(doc) {
+		p.parseGroceryOrder(doc, order)
+		return order, nil
+	}
+	if isDigitalOrderPage(doc) {
+		p.parseDigitalOrder(doc, order)
+		return order, nil
+	}
+	order.Kind = OrderKindPhysical
+
 	// Parse order summary section for pricing
 	p.parseOrderSummary(doc, order)
 
 	// Parse items from shipments
 	p.parseShipmentItems(doc, order)
 
+	// Refund amounts not shown per shipment come from the charge summary
+	reconcileRefunds(order)
+
+	if order.ID == "" && order.Total.IsZero() && len(order.Items) == 0 && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeOrderDetails, Err: ErrEncryptedContent}
+	}
+
 	return order, nil
 }
 
@@ -155,37 +313,61 @@ func (p *Parser) parseOrderSummary(doc *goquery.Document, order *Order) {
 	// Find the order summary section
 	doc.Find("#od-subtotals, [data-component='chargeSummary']").Each(func(i int, s *goquery.Selection) {
 		s.Find(".od-line-item-row").Each(func(j int, row *goquery.Selection) {
-			label := strings.ToLower(strings.TrimSpace(row.Find(".od-line-item-row-label").Text()))
+			label := strings.Join(strings.Fields(row.Find(".od-line-item-row-label").Text()), " ")
 			valueText := strings.TrimSpace(row.Find(".od-line-item-row-content").Text())
-			value := parsePrice(valueText)
-
-			switch {
-			case strings.Contains(label, "item") && strings.Contains(label, "subtotal"):
-				order.Subtotal = value
-			case strings.Contains(label, "shipping") || strings.Contains(label, "handling"):
-				order.ShippingFees = value
-			case strings.Contains(label, "tax"):
-				order.Tax = value
-			case strings.Contains(label, "grand total"):
-				order.Total = value
-			}
+			p.addAdjustment(order, label, valueText)
 		})
 	})
 
 	// Alternative parsing if above didn't work
-	if order.Total == 0 {
+	if order.Total.IsZero() {
 		doc.Find("span:contains('Grand Total')").Each(func(i int, s *goquery.Selection) {
 			// Find the next sibling or parent's next element with the price
 			parent := s.Parent()
 			priceText := parent.Next().Text()
-			if price := parsePrice(priceText); price > 0 {
+			if price := p.marketplace.ParsePrice(priceText); price.Units > 0 {
 				order.Total = price
 			}
 		})
 	}
 }
 
-// parseShipmentItems extracts items from shipment sections
+// addAdjustment adds a charge summary row to the order and updates the
+// subtotal, shipping, tax and total it contributes to
+func (p *Parser) addAdjustment(order *Order, label, valueText string) {
+	if label == "" || valueText == "" {
+		return
+	}
+
+	adj := &Adjustment{
+		Type:   classifyAdjustment(label),
+		Label:  label,
+		Amount: p.marketplace.ParsePrice(valueText),
+	}
+	// Discounts and credits reduce the total whether or not Amazon shows the minus sign
+	if adj.Type.isCredit() || strings.ContainsAny(valueText, "-−") {
+		adj.Amount.Units = -abs(adj.Amount.Units)
+	}
+	order.Adjustments = append(order.Adjustments, adj)
+
+	switch adj.Type {
+	case AdjustmentSubtotal:
+		order.Subtotal = adj.Amount
+	case AdjustmentShipping:
+		order.ShippingFees = order.ShippingFees.Add(adj.Amount)
+	case AdjustmentTax:
+		order.Tax = order.Tax.Add(adj.Amount)
+	case AdjustmentTip:
+		order.Tip = order.Tip.Add(adj.Amount)
+	case AdjustmentBagFee:
+		order.BagFees = order.BagFees.Add(adj.Amount)
+	case AdjustmentGrandTotal:
+		order.Total = adj.Amount
+	}
+}
+
+// parseShipmentItems extracts items from shipment sections, then groups them
+// into the order's shipments
 func (p *Parser) parseShipmentItems(doc *goquery.Document, order *Order) {
 	// Build a map of ASIN to item name by finding all title links first
 	asinToName := make(map[string]string)
@@ -236,7 +418,7 @@ func (p *Parser) parseShipmentItems(doc *goquery.Document, order *Order) {
 		if priceText == "" {
 			priceText = priceDiv.Find(".a-price").Text()
 		}
-		price := parsePrice(priceText)
+		price := p.marketplace.ParsePrice(priceText)
 
 		// Associate price with item by index
 		if i < len(order.Items) {
@@ -255,7 +437,7 @@ func (p *Parser) parseShipmentItems(doc *goquery.Document, order *Order) {
 		if qty > 0 && i < len(order.Items) {
 			order.Items[i].Quantity = qty
 			// Update line total
-			order.Items[i].Price = order.Items[i].UnitPrice * qty
+			order.Items[i].Price = order.Items[i].UnitPrice.Mul(qty)
 		}
 	})
 
@@ -266,14 +448,40 @@ func (p *Parser) parseShipmentItems(doc *goquery.Document, order *Order) {
 			item.Price = item.UnitPrice
 		}
 	}
+
+	p.parseShipments(doc, order)
 }
 
 // Helper functions
 
+// detectBlockedPage checks whether Amazon served a CAPTCHA or sign-in page
+// instead of the requested content
+func detectBlockedPage(s *goquery.Selection) error {
+	title := strings.ToLower(s.Find("title").Text())
+	if strings.Contains(title, "robot check") ||
+		s.Find("form[action*='validateCaptcha'], #captchacharacters").Length() > 0 {
+		return ErrCaptcha
+	}
+
+	// Check for login form fields - NOT just /ap/signin which appears in nav
+	if s.Find("input#ap_email, input#ap_password, input[name='email'][type='email'], form[name='signIn']").Length() > 0 &&
+		s.Find(".order-card").Length() == 0 {
+		return ErrLoginRequired
+	}
+
+	return nil
+}
+
+// isEncrypted checks whether a selection contains client-side encrypted content
+func isEncrypted(s *goquery.Selection) bool {
+	return s.Find(".csd-encrypted-sensitive").Length() > 0 || s.HasClass("csd-encrypted-sensitive")
+}
+
 // extractOrderIDFromURL extracts order ID from a URL query parameter
 func extractOrderIDFromURL(url string) string {
-	// Match orderID=XXX-XXXXXXX-XXXXXXX
-	re := regexp.MustCompile(`orderID=(\d{3}-\d{7}-\d{7})`)
+	// Match orderID=XXX-XXXXXXX-XXXXXXX or D01-XXXXXXX-XXXXXXX for digital
+	// orders (tracking and returns links use orderId)
+	re := regexp.MustCompile(`(?i)orderID=((?:\d{3}|D\d{2})-\d{7}-\d{7})`)
 	matches := re.FindStringSubmatch(url)
 	if len(matches) > 1 {
 		return matches[1]
@@ -283,8 +491,8 @@ func extractOrderIDFromURL(url string) string {
 
 // extractOrderIDFromText extracts order ID from text content
 func extractOrderIDFromText(text string) string {
-	// Match XXX-XXXXXXX-XXXXXXX pattern
-	re := regexp.MustCompile(`(\d{3}-\d{7}-\d{7})`)
+	// Match XXX-XXXXXXX-XXXXXXX or digital D01-XXXXXXX-XXXXXXX pattern
+	re := regexp.MustCompile(`((?:\d{3}|D\d{2})-\d{7}-\d{7})`)
 	matches := re.FindStringSubmatch(text)
 	if len(matches) > 1 {
 		return matches[1]
@@ -294,8 +502,9 @@ func extractOrderIDFromText(text string) string {
 
 // extractASINFromURL extracts ASIN from an Amazon product URL
 func extractASINFromURL(url string) string {
-	// Match /dp/XXXXXXXXXX or asin=XXXXXXXXXX
-	re := regexp.MustCompile(`(?:/dp/|asin=)([A-Z0-9]{10})`)
+	// Match /dp/XXXXXXXXXX, /gp/product/XXXXXXXXXX (apps, music),
+	// /gp/video/detail/XXXXXXXXXX (Prime Video) or asin=XXXXXXXXXX
+	re := regexp.MustCompile(`(?:/dp/|/gp/product/|/gp/video/detail/|asin=)([A-Z0-9]{10})`)
 	matches := re.FindStringSubmatch(url)
 	if len(matches) > 1 {
 		return matches[1]
@@ -303,25 +512,9 @@ func extractASINFromURL(url string) string {
 	return ""
 }
 
-// parsePrice extracts a price value from text like "$42.37" or "USD 42.37"
-func parsePrice(text string) float64 {
-	// Remove currency symbols and clean up
-	text = strings.TrimSpace(text)
-	text = strings.ReplaceAll(text, "$", "")
-	text = strings.ReplaceAll(text, "USD", "")
-	text = strings.ReplaceAll(text, ",", "")
-	text = strings.TrimSpace(text)
-
-	// Extract first number pattern
-	re := regexp.MustCompile(`(\d+\.?\d*)`)
-	matches := re.FindStringSubmatch(text)
-	if len(matches) > 1 {
-		price, err := strconv.ParseFloat(matches[1], 64)
-		if err == nil {
-			return price
-		}
-	}
-	return 0
+// parsePrice extracts a US dollar amount from text like "$42.37" or "USD 42.37"
+func parsePrice(text string) Money {
+	return MarketplaceUS.ParsePrice(text)
 }
 
 // parseQuantity extracts quantity from text like "Qty: 2" or "x2"
@@ -350,53 +543,9 @@ func parseQuantity(text string) float64 {
 	return 1 // Default to 1 if not found
 }
 
-// containsMonth checks if text contains a month name
-func containsMonth(text string) bool {
-	months := []string{
-		"january", "february", "march", "april", "may", "june",
-		"july", "august", "september", "october", "november", "december",
-		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
-	}
-	lower := strings.ToLower(text)
-	for _, month := range months {
-		if strings.Contains(lower, month) {
-			return true
-		}
-	}
-	return false
-}
-
 // parseAmazonDate parses various Amazon date formats
 func parseAmazonDate(text string) (time.Time, error) {
-	text = strings.TrimSpace(text)
-
-	// Remove common prefixes
-	prefixes := []string{"Ordered", "Order placed", "Placed on"}
-	for _, prefix := range prefixes {
-		text = strings.TrimPrefix(text, prefix)
-		text = strings.TrimSpace(text)
-	}
-
-	// Common Amazon date formats
-	formats := []string{
-		"January 2, 2006",
-		"Jan 2, 2006",
-		"January 02, 2006",
-		"Jan 02, 2006",
-		"2 January 2006",
-		"02 January 2006",
-		"2006-01-02",
-		"01/02/2006",
-		"1/2/2006",
-	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, text); err == nil {
-			return t, nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("unable to parse date: %s", text)
+	return MarketplaceUS.ParseDate(text)
 }
 
 // ParseTransactions parses the transactions page for an order
@@ -407,6 +556,10 @@ func (p *Parser) ParseTransactions(r io.Reader) ([]*Transaction, error) {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeTransactions, Err: err}
+	}
+
 	var transactions []*Transaction
 	var currentStatus string
 	var currentDate time.Time
@@ -426,7 +579,7 @@ func (p *Parser) ParseTransactions(r io.Reader) ([]*Transaction, error) {
 		if dateText == "" {
 			dateText = strings.TrimSpace(dateDiv.Text())
 		}
-		if date, err := parseAmazonDate(dateText); err == nil {
+		if date, err := p.marketplace.ParseDate(dateText); err == nil {
 			currentDate = date
 		}
 	})
@@ -446,7 +599,7 @@ func (p *Parser) ParseTransactions(r io.Reader) ([]*Transaction, error) {
 				if dateText == "" {
 					dateText = strings.TrimSpace(prev.Text())
 				}
-				if date, err := parseAmazonDate(dateText); err == nil {
+				if date, err := p.marketplace.ParseDate(dateText); err == nil {
 					tx.Date = date
 				}
 			}
@@ -461,12 +614,15 @@ func (p *Parser) ParseTransactions(r io.Reader) ([]*Transaction, error) {
 				tx.CardType, tx.LastFour = parsePaymentMethod(paymentMethod)
 			}
 
-			// Amount is in the last column with bold text (negative value like "-$44.91")
+			// Amount is in the last column with bold text: charges are shown
+			// as "-$44.91" and refunds as "+$44.91"
 			amountText := strings.TrimSpace(row.Find(".a-column.a-span3 .a-text-bold").Text())
 			if amountText != "" {
-				// Remove the negative sign and parse
-				amountText = strings.TrimPrefix(amountText, "-")
-				tx.Amount = parsePrice(amountText)
+				tx.Amount = p.marketplace.ParsePrice(amountText)
+				if isRefundRow(amountText, row.Find("a[href*='orderID=']").First().Text()) {
+					tx.Amount = tx.Amount.Neg()
+					tx.Status = "Refunded"
+				}
 			}
 
 			// Look for order ID link
@@ -489,7 +645,7 @@ func (p *Parser) ParseTransactions(r io.Reader) ([]*Transaction, error) {
 		})
 
 		// Only add if we have meaningful data
-		if tx.Amount > 0 || tx.PaymentMethod != "" {
+		if !tx.Amount.IsZero() || tx.PaymentMethod != "" {
 			transactions = append(transactions, tx)
 		}
 	})
@@ -499,6 +655,10 @@ func (p *Parser) ParseTransactions(r io.Reader) ([]*Transaction, error) {
 		transactions = p.parseTransactionsAlternative(doc)
 	}
 
+	if len(transactions) == 0 && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeTransactions, Err: ErrEncryptedContent}
+	}
+
 	return transactions, nil
 }
 
@@ -524,8 +684,8 @@ func (p *Parser) parseTransactionsAlternative(doc *goquery.Document) []*Transact
 		// Find date
 		container.Find("span").Each(func(j int, s *goquery.Selection) {
 			text := strings.TrimSpace(s.Text())
-			if containsMonth(text) && !strings.Contains(text, "Order") {
-				if date, err := parseAmazonDate(text); err == nil {
+			if !strings.Contains(text, "Order") {
+				if date, err := p.marketplace.ParseDate(text); err == nil {
 					currentDate = date
 				}
 			}
@@ -549,11 +709,14 @@ func (p *Parser) parseTransactionsAlternative(doc *goquery.Document) []*Transact
 				parent := s.Parent()
 				amountText := strings.TrimSpace(parent.Find(".a-span3 .a-text-bold, .a-text-right .a-text-bold").Text())
 				if amountText != "" {
-					amountText = strings.TrimPrefix(amountText, "-")
-					tx.Amount = parsePrice(amountText)
+					tx.Amount = p.marketplace.ParsePrice(amountText)
+					if isRefundRow(amountText, currentStatus) {
+						tx.Amount = tx.Amount.Neg()
+						tx.Status = "Refunded"
+					}
 				}
 
-				if tx.Amount > 0 {
+				if !tx.Amount.IsZero() {
 					transactions = append(transactions, tx)
 				}
 			}
@@ -563,6 +726,18 @@ func (p *Parser) parseTransactionsAlternative(doc *goquery.Document) []*Transact
 	return transactions
 }
 
+// refundLabelPattern matches a row label or status that starts with
+// "Refund" or "Refunded", but not "Refundable" or text that merely mentions
+// a refund policy
+var refundLabelPattern = regexp.MustCompile(`(?i)^refund(ed)?\b`)
+
+// isRefundRow reports whether a transaction row is money returned rather
+// than a charge, from the sign of its amount ("+$19.99") or its own label
+// or status, e.g. "Refund: Order #114-..."
+func isRefundRow(amountText, label string) bool {
+	return strings.HasPrefix(amountText, "+") || refundLabelPattern.MatchString(strings.TrimSpace(label))
+}
+
 // parsePaymentMethod extracts card type and last 4 digits from payment method string
 // e.g., "Prime Visa ****1211" -> ("Visa", "1211")
 func parsePaymentMethod(method string) (cardType, lastFour string) {
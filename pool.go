@@ -0,0 +1,76 @@
+package amazon
+
+import (
+	"context"
+	"sync"
+)
+
+// forEach calls fn for each index in [0, n) using up to the client's
+// configured number of workers. Requests made by fn still pass through the
+// client's global rate limit.
+//
+// It returns the error fn returned for each index. Dispatch stops early if
+// ctx is cancelled or fn reports that Amazon is blocking pages, in which case
+// that error is also returned and indexes that never ran have a nil error.
+func (c *Client) forEach(ctx context.Context, n int, fn func(ctx context.Context, i int) error) ([]error, error) {
+	errs := make([]error, n)
+	if n == 0 {
+		return errs, nil
+	}
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := c.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		wg       sync.WaitGroup
+		stopOnce sync.Once
+		stopErr  error
+	)
+	indexes := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				err := fn(workerCtx, i)
+				if err == nil {
+					continue
+				}
+				errs[i] = err
+
+				// Remaining pages will be blocked the same way, so stop early
+				if isBlockedPageError(err) {
+					stopOnce.Do(func() {
+						stopErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case <-workerCtx.Done():
+			break dispatch
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if stopErr != nil {
+		return errs, stopErr
+	}
+	return errs, ctx.Err()
+}
@@ -0,0 +1,141 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePageSource serves generated order pages and tracks how many fetches overlap
+type fakePageSource struct {
+	orderIDs []string
+	failIDs  map[string]error
+
+	mu            sync.Mutex
+	inFlight      int
+	maxConcurrent int
+}
+
+func (s *fakePageSource) FetchPage(ctx context.Context, pageURL string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.maxConcurrent {
+		s.maxConcurrent = s.inFlight
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	// Hold the page long enough for workers to overlap
+	time.Sleep(10 * time.Millisecond)
+
+	if strings.Contains(pageURL, "/your-orders/orders") {
+		if strings.Contains(pageURL, "startIndex") {
+			return nil, ErrPageNotFound
+		}
+		var b strings.Builder
+		for _, id := range s.orderIDs {
+			fmt.Fprintf(&b, `<div class="order-card"><a href="/your-orders/order-details?orderID=%s">details</a></div>`, id)
+		}
+		return io.NopCloser(strings.NewReader(b.String())), nil
+	}
+
+	orderID := queryParam(pageURL, "orderID")
+	if orderID == "" {
+		orderID = queryParam(pageURL, "transactionTag")
+	}
+	if err, ok := s.failIDs[orderID]; ok {
+		return nil, err
+	}
+
+	html := fmt.Sprintf(`<span>Order # %s</span><div id="od-subtotals"></div>`, orderID)
+	return io.NopCloser(strings.NewReader(html)), nil
+}
+
+func newFakeSourceClient(t *testing.T, src PageSource, concurrency int) *Client {
+	t.Helper()
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithPageSource(src),
+		WithConcurrency(concurrency),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+func TestFetchOrders_ConcurrentPreservesOrder(t *testing.T) {
+	src := &fakePageSource{
+		orderIDs: []string{
+			"111-0000001-0000001", "111-0000002-0000002", "111-0000003-0000003",
+			"111-0000004-0000004", "111-0000005-0000005", "111-0000006-0000006",
+		},
+		failIDs: map[string]error{"111-0000003-0000003": errors.New("connection reset")},
+	}
+	client := newFakeSourceClient(t, src, 3)
+
+	orders, err := client.FetchOrders(context.Background(), FetchOptions{Year: 2025, IncludeDetails: true})
+
+	var orderErrs OrderErrors
+	if !errors.As(err, &orderErrs) || len(orderErrs) != 1 || orderErrs[0].OrderID != "111-0000003-0000003" {
+		t.Fatalf("Expected one OrderError for 111-0000003-0000003, got %v", err)
+	}
+
+	if len(orders) != len(src.orderIDs) {
+		t.Fatalf("Expected %d orders, got %d", len(src.orderIDs), len(orders))
+	}
+	for i, order := range orders {
+		if order.ID != src.orderIDs[i] {
+			t.Errorf("Order %d: expected %s, got %s", i, src.orderIDs[i], order.ID)
+		}
+	}
+
+	if src.maxConcurrent < 2 || src.maxConcurrent > 3 {
+		t.Errorf("Expected between 2 and 3 concurrent fetches, got %d", src.maxConcurrent)
+	}
+}
+
+func TestFetchOrders_BlockedStopsBatch(t *testing.T) {
+	src := &fakePageSource{
+		orderIDs: []string{"111-0000001-0000001", "111-0000002-0000002", "111-0000003-0000003"},
+		failIDs:  map[string]error{"111-0000001-0000001": &PageError{PageType: PageTypeOrderDetails, Err: ErrCaptcha}},
+	}
+	client := newFakeSourceClient(t, src, 1)
+
+	_, err := client.FetchOrders(context.Background(), FetchOptions{Year: 2025, IncludeDetails: true})
+	if !errors.Is(err, ErrCaptcha) {
+		t.Fatalf("Expected ErrCaptcha, got %v", err)
+	}
+}
+
+func TestFetchAllTransactions_Concurrent(t *testing.T) {
+	orderIDs := []string{"111-0000001-0000001", "111-0000002-0000002", "111-0000003-0000003", "111-0000004-0000004"}
+	src := &fakePageSource{
+		failIDs: map[string]error{"111-0000004-0000004": errors.New("timeout")},
+	}
+	client := newFakeSourceClient(t, src, 4)
+
+	result, err := client.FetchAllTransactions(context.Background(), orderIDs)
+
+	var orderErrs OrderErrors
+	if !errors.As(err, &orderErrs) || len(orderErrs) != 1 {
+		t.Fatalf("Expected one OrderError, got %v", err)
+	}
+	if len(result) != 3 {
+		t.Errorf("Expected transactions for 3 orders, got %d", len(result))
+	}
+	if src.maxConcurrent < 2 {
+		t.Errorf("Expected concurrent fetches, got max %d", src.maxConcurrent)
+	}
+}
@@ -0,0 +1,176 @@
+package amazon
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a context-aware token bucket. Tokens refill at one per
+// interval, up to burst tokens, and each request takes one token.
+type RateLimiter struct {
+	interval time.Duration
+	burst    int
+	tokens   float64
+	last     time.Time
+	mu       sync.Mutex
+}
+
+// NewRateLimiter creates a token bucket allowing one request per interval
+// with bursts of up to burst requests. An interval of 0 disables limiting.
+func NewRateLimiter(interval time.Duration, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+	}
+}
+
+// Wait blocks until a request is allowed or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	_, err := l.wait(ctx)
+	return err
+}
+
+// wait is Wait, also returning how long the caller was held back
+func (l *RateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	if l == nil || l.interval <= 0 {
+		return 0, ctx.Err()
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		if err := ctx.Err(); err != nil {
+			l.release()
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Give the token back so cancelled waits don't starve other callers
+		l.release()
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+// release returns a token taken by a wait that was then abandoned
+func (l *RateLimiter) release() {
+	if l == nil || l.interval <= 0 {
+		return
+	}
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
+
+// reserve takes a token and returns how long the caller must wait for it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+	l.last = now
+
+	// Tokens may go negative: each waiter reserves its own future slot
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// Limiter enforces a global request budget plus optional per-endpoint budgets
+// for the order list, order details and transactions pages.
+// A Limiter can be shared by several Clients for the same account with WithLimiter.
+type Limiter struct {
+	global    *RateLimiter
+	endpoints map[PageType]*RateLimiter
+	mu        sync.RWMutex
+}
+
+// NewLimiter creates a limiter with the given global budget.
+// A nil global limiter means requests are only limited per endpoint.
+func NewLimiter(global *RateLimiter) *Limiter {
+	return &Limiter{
+		global:    global,
+		endpoints: make(map[PageType]*RateLimiter),
+	}
+}
+
+// SetEndpointLimit sets a separate budget for one kind of page
+func (l *Limiter) SetEndpointLimit(pageType PageType, limiter *RateLimiter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.endpoints[pageType] = limiter
+}
+
+// Wait blocks until a request for the given kind of page is allowed by both
+// its endpoint budget and the global budget, or ctx is done
+func (l *Limiter) Wait(ctx context.Context, pageType PageType) error {
+	_, err := l.wait(ctx, pageType)
+	return err
+}
+
+// wait is Wait, also returning how long the caller was held back in total
+func (l *Limiter) wait(ctx context.Context, pageType PageType) (time.Duration, error) {
+	l.mu.RLock()
+	endpoint := l.endpoints[pageType]
+	l.mu.RUnlock()
+
+	endpointDelay, err := endpoint.wait(ctx)
+	if err != nil {
+		return endpointDelay, err
+	}
+	globalDelay, err := l.global.wait(ctx)
+	if err != nil {
+		// The request won't be sent, so it mustn't use up the endpoint budget
+		endpoint.release()
+		return 0, err
+	}
+	return endpointDelay + globalDelay, nil
+}
+
+// EndpointRateLimit configures the budget for one kind of page
+type EndpointRateLimit struct {
+	Interval time.Duration
+	Burst    int
+}
+
+// pageTypeForURL returns which endpoint budget a request URL counts against
+func pageTypeForURL(u *url.URL) PageType {
+	switch {
+	case strings.Contains(u.Path, "/order-details"), strings.Contains(u.Path, "/order-summary"):
+		return PageTypeOrderDetails
+	case strings.Contains(u.Path, "/transactions"):
+		return PageTypeTransactions
+	case strings.Contains(u.Path, "/ship-track"), strings.Contains(u.Path, "/progress-tracker"):
+		return PageTypeTracking
+	case strings.Contains(u.Path, "/summary/print"):
+		return PageTypeInvoice
+	case strings.Contains(u.Path, "/returns"):
+		return PageTypeReturns
+	case strings.Contains(u.Path, "/auto-deliveries"), strings.Contains(u.Path, "/yourmembershipsandsubscriptions"):
+		return PageTypeSubscriptions
+	case strings.Contains(u.Path, "/your-orders/orders"):
+		return PageTypeOrderList
+	}
+	return ""
+}
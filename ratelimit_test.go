@@ -0,0 +1,131 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	l := NewRateLimiter(50*time.Millisecond, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("Expected burst of 3 to be immediate, took %v", elapsed)
+	}
+
+	// The fourth request waits for a token to refill
+	start = time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected fourth request to wait, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_ContextCancel(t *testing.T) {
+	l := NewRateLimiter(time.Hour, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected cancellation to interrupt the wait, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	l := NewRateLimiter(0, 1)
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+}
+
+func TestLimiter_EndpointBudgets(t *testing.T) {
+	l := NewLimiter(nil)
+	l.SetEndpointLimit(PageTypeOrderDetails, NewRateLimiter(time.Hour, 1))
+	ctx := context.Background()
+
+	if err := l.Wait(ctx, PageTypeOrderDetails); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+
+	// Order details budget is exhausted but other endpoints are unaffected
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(ctx, PageTypeOrderList); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("Expected order list requests to be unlimited, took %v", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.Wait(cancelled, PageTypeOrderDetails); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Canceled for exhausted endpoint, got %v", err)
+	}
+}
+
+func TestLimiter_CancelledGlobalWaitReturnsEndpointToken(t *testing.T) {
+	endpoint := NewRateLimiter(time.Hour, 2)
+	l := NewLimiter(NewRateLimiter(time.Hour, 1))
+	l.SetEndpointLimit(PageTypeOrderDetails, endpoint)
+
+	if err := l.Wait(context.Background(), PageTypeOrderDetails); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+
+	// The endpoint has a token left, but the global budget is exhausted
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, PageTypeOrderDetails); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	endpoint.mu.Lock()
+	tokens := endpoint.tokens
+	endpoint.mu.Unlock()
+	if tokens < 0.99 {
+		t.Errorf("Expected the endpoint token to be returned, %.2f left", tokens)
+	}
+}
+
+func TestPageTypeForURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected PageType
+	}{
+		{MarketplaceUS.BaseURL() + ordersPath + "?timeFilter=year-2025", PageTypeOrderList},
+		{MarketplaceUS.BaseURL() + orderDetailsPath + "?orderID=114-9733092-9360267", PageTypeOrderDetails},
+		{MarketplaceUS.BaseURL() + transactionsPath + "?transactionTag=114-9733092-9360267", PageTypeTransactions},
+		{MarketplaceUS.BaseURL() + "/gp/help", ""},
+	}
+
+	for _, tc := range tests {
+		u, _ := url.Parse(tc.url)
+		if result := pageTypeForURL(u); result != tc.expected {
+			t.Errorf("pageTypeForURL(%q) = %q, want %q", tc.url, result, tc.expected)
+		}
+	}
+}
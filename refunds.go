@@ -0,0 +1,197 @@
+package amazon
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var refundPattern = regexp.MustCompile(`(?i)refund`)
+
+// Transaction returns the refund as a transaction with a negative amount,
+// so it reverses the original charge when transactions are summed
+func (r *Refund) Transaction() *Transaction {
+	return &Transaction{
+		OrderID: r.OrderID,
+		Date:    r.Date,
+		Amount:  r.Amount.Neg(),
+		Status:  "Refunded",
+	}
+}
+
+// parseRefundText reads the amount and date of a refund from status text
+// like "Return complete. Your refund of $19.99 was issued on January 10".
+// Only text after the word "refund" is used, so item prices and delivery
+// dates earlier in the text aren't mistaken for it.
+func (p *Parser) parseRefundText(refund *Refund, text string, orderDate time.Time) {
+	loc := refundPattern.FindStringIndex(text)
+	if loc == nil {
+		return
+	}
+	text = text[loc[0]:]
+
+	if amount, ok := p.marketplace.findPrice(text); ok {
+		refund.Amount = amount
+	}
+	refund.Date = p.statusDate(text, orderDate, true)
+}
+
+// parseReturnedShipment builds a refund for a shipment whose items were returned
+func (p *Parser) parseReturnedShipment(box *goquery.Selection, order *Order, shipment *Shipment) *Refund {
+	refund := &Refund{
+		OrderID: order.ID,
+		Items:   shipment.Items,
+		Amount:  Money{Currency: p.marketplace.Currency},
+		Status:  shipment.Status,
+	}
+
+	text := box.Find("[data-component='shipmentStatus'], .yohtmlc-shipment-status-secondaryText, .od-status-message").Text()
+	p.parseRefundText(refund, strings.Join(strings.Fields(text), " "), order.Date)
+	return refund
+}
+
+// reconcileRefunds fills in refund amounts from the charge summary's refund
+// total when the shipment statuses don't show them. The remainder goes to
+// the one refund without an amount, or to a new refund if every return
+// already has one.
+func reconcileRefunds(order *Order) {
+	var total Money
+	for _, adj := range order.Adjustments {
+		if adj.Type == AdjustmentRefund {
+			total = total.Add(Money{Units: abs(adj.Amount.Units), Currency: adj.Amount.Currency})
+		}
+	}
+	if total.IsZero() {
+		return
+	}
+
+	known := Money{Currency: total.Currency}
+	var missing []*Refund
+	for _, refund := range order.Refunds {
+		if refund.Amount.IsZero() {
+			missing = append(missing, refund)
+		} else {
+			known = known.Add(refund.Amount)
+		}
+	}
+
+	remaining := total.Sub(known)
+	if remaining.Units <= 0 {
+		return
+	}
+
+	switch len(missing) {
+	case 0:
+		order.Refunds = append(order.Refunds, &Refund{
+			OrderID: order.ID,
+			Amount:  remaining,
+			Status:  "Refunded",
+		})
+	case 1:
+		missing[0].Amount = remaining
+	}
+}
+
+// refundDateTolerance is how far apart a refund's issue date and its
+// transaction's date may be, since card refunds can post a few days later
+const refundDateTolerance = 3 * 24 * time.Hour
+
+// withRefundTransactions adds a negative transaction for each issued refund
+// that the transactions page doesn't already list. Each listed transaction
+// accounts for at most one refund, so two equal refunds need two transactions.
+func withRefundTransactions(transactions []*Transaction, refunds []*Refund) []*Transaction {
+	matched := make(map[int]bool)
+	for _, refund := range refunds {
+		if refund.Amount.IsZero() {
+			continue
+		}
+
+		listed := false
+		for i, tx := range transactions {
+			if !matched[i] && refundMatches(refund, tx) {
+				matched[i] = true
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			transactions = append(transactions, refund.Transaction())
+			matched[len(transactions)-1] = true
+		}
+	}
+	return transactions
+}
+
+// refundMatches reports whether tx is the transaction for refund: the same
+// amount negated, in the same currency, and close to the refund date when
+// both dates are known
+func refundMatches(refund *Refund, tx *Transaction) bool {
+	if !sameAmount(tx.Amount, refund.Amount.Neg()) {
+		return false
+	}
+	if refund.Date.IsZero() || tx.Date.IsZero() {
+		return true
+	}
+	diff := tx.Date.Sub(refund.Date)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= refundDateTolerance
+}
+
+// ParseReturns parses the returns center page and returns one refund per
+// return, including returns whose refund hasn't been issued yet
+func (p *Parser) ParseReturns(r io.Reader) ([]*Refund, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeReturns, Err: err}
+	}
+
+	var refunds []*Refund
+	cards := doc.Find("a[href*='orderID='], a[href*='orderId=']").Closest("[data-component='returnCard'], .a-box")
+	cards.Each(func(i int, card *goquery.Selection) {
+		refund := &Refund{Amount: Money{Currency: p.marketplace.Currency}}
+
+		card.Find("a[href*='orderID='], a[href*='orderId=']").EachWithBreak(func(j int, link *goquery.Selection) bool {
+			refund.OrderID = extractOrderIDFromURL(link.AttrOr("href", ""))
+			return refund.OrderID == ""
+		})
+		if refund.OrderID == "" {
+			return
+		}
+
+		seen := make(map[string]bool)
+		card.Find("a[href*='/dp/']").Each(func(j int, link *goquery.Selection) {
+			asin := extractASINFromURL(link.AttrOr("href", ""))
+			name := strings.TrimSpace(link.Text())
+			if asin == "" || seen[asin] || len(name) <= 5 {
+				return
+			}
+			seen[asin] = true
+			refund.Items = append(refund.Items, &OrderItem{ASIN: asin, Name: name, Quantity: 1})
+		})
+
+		status := card.Find("[data-component='returnStatus']").First()
+		if status.Length() == 0 {
+			status = card.Find(".a-text-bold").First()
+		}
+		refund.Status = strings.Join(strings.Fields(status.Text()), " ")
+
+		p.parseRefundText(refund, strings.Join(strings.Fields(card.Text()), " "), time.Time{})
+		refunds = append(refunds, refund)
+	})
+
+	if len(refunds) == 0 && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeReturns, Err: ErrEncryptedContent}
+	}
+
+	return refunds, nil
+}
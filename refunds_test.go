@@ -0,0 +1,253 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testReturnedOrderPage = `<html><body>
+<span>Order # 114-9733092-9360267</span>
+<div id="od-subtotals">
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Item(s) Subtotal:</span><span class="od-line-item-row-content">$29.99</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Grand Total:</span><span class="od-line-item-row-content">$29.99</span></div>
+	<div class="od-line-item-row"><span class="od-line-item-row-label">Refund Total</span><span class="od-line-item-row-content">$19.99</span></div>
+</div>
+<div data-component="shipments">
+	<div class="a-box shipment">
+		<div data-component="shipmentStatus"><span class="a-text-bold">Return complete</span><span>Your refund of $19.99 was issued on January 10.</span></div>
+		<div data-component="shipmentsLeftGrid">
+			<a href="/dp/B09XV8WDY6">USB-C Charging Cable</a>
+			<div data-component="unitPrice"><span class="a-offscreen">$19.99</span></div>
+		</div>
+	</div>
+	<div class="a-box shipment">
+		<div data-component="shipmentStatus"><span class="a-text-bold">Delivered January 3</span></div>
+		<div data-component="shipmentsLeftGrid">
+			<a href="/dp/B0FJDMHXD1">Dish Soap, 3 Pack</a>
+			<div data-component="unitPrice"><span class="a-offscreen">$10.00</span></div>
+		</div>
+	</div>
+</div>
+</body></html>`
+
+const testRefundTransactionsPage = `<html><body>
+<div class="apx-transactions-line-item-component-container">
+	<div data-pmts-component-id="row">
+		<div class="a-column a-span9"><span class="a-text-bold">Prime Visa ****1211</span></div>
+		<div class="a-column a-span3"><span class="a-text-bold">+$19.99</span></div>
+		<div class="a-column a-span12"><a href="/your-orders/order-details?orderID=114-9733092-9360267">Refund: Order #114-9733092-9360267</a></div>
+	</div>
+</div>
+<div class="apx-transactions-line-item-component-container">
+	<div data-pmts-component-id="row">
+		<div class="a-column a-span9"><span class="a-text-bold">Prime Visa ****1211</span></div>
+		<div class="a-column a-span3"><span class="a-text-bold">-$29.99</span></div>
+		<div class="a-column a-span12"><a href="/your-orders/order-details?orderID=114-9733092-9360267">Order #114-9733092-9360267</a></div>
+	</div>
+</div>
+</body></html>`
+
+const testReturnsPage = `<html><body>
+<div class="a-box" data-component="returnCard">
+	<div data-component="returnStatus">Refund issued</div>
+	<a href="/your-orders/order-details?orderId=114-9733092-9360267">View order</a>
+	<a href="/dp/B09XV8WDY6">USB-C Charging Cable</a>
+	<span>Refund of $19.99 issued on Jan 10, 2025</span>
+</div>
+<div class="a-box" data-component="returnCard">
+	<div data-component="returnStatus">Return started</div>
+	<a href="/your-orders/order-details?orderID=113-7382612-3141857">View order</a>
+	<a href="/dp/B0D6VC4PM6">Laptop Stand</a>
+	<span>Drop off by February 3</span>
+</div>
+</body></html>`
+
+func TestParseOrderDetails_Refunds(t *testing.T) {
+	parser := NewParser()
+	parser.now = func() time.Time { return time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC) }
+
+	order, err := parser.ParseOrderDetails(strings.NewReader(testReturnedOrderPage))
+	if err != nil {
+		t.Fatalf("ParseOrderDetails failed: %v", err)
+	}
+
+	if len(order.Refunds) != 1 {
+		t.Fatalf("Expected 1 refund, got %d", len(order.Refunds))
+	}
+	refund := order.Refunds[0]
+	if refund.OrderID != "114-9733092-9360267" || refund.Amount != NewMoney(1999, "USD") {
+		t.Errorf("Unexpected refund: order=%s amount=%s", refund.OrderID, refund.Amount)
+	}
+	if got := refund.Date.Format("2006-01-02"); got != "2025-01-10" {
+		t.Errorf("Expected refund date 2025-01-10, got %s", got)
+	}
+	if len(refund.Items) != 1 || refund.Items[0] != order.Items[0] {
+		t.Errorf("Expected refund to reference the returned item")
+	}
+	if order.Shipments[0].State != ShipmentReturned {
+		t.Errorf("Expected returned shipment, got %s", order.Shipments[0].State)
+	}
+
+	// The refund total is informational and doesn't change what was charged
+	if err := order.VerifyTotals(); err != nil {
+		t.Errorf("VerifyTotals failed: %v", err)
+	}
+}
+
+func TestReconcileRefunds(t *testing.T) {
+	refundTotal := &Adjustment{Type: AdjustmentRefund, Label: "Refund Total", Amount: NewMoney(2500, "USD")}
+
+	// A return without an amount takes the refund total
+	returned := &Refund{Status: "Return complete"}
+	order := &Order{ID: "114-9733092-9360267", Adjustments: []*Adjustment{refundTotal}, Refunds: []*Refund{returned}}
+	reconcileRefunds(order)
+	if len(order.Refunds) != 1 || returned.Amount != NewMoney(2500, "USD") {
+		t.Errorf("Expected return to take the refund total, got %s", returned.Amount)
+	}
+
+	// Without any returns, the refund total becomes its own refund
+	order = &Order{ID: "114-9733092-9360267", Adjustments: []*Adjustment{refundTotal}}
+	reconcileRefunds(order)
+	if len(order.Refunds) != 1 || order.Refunds[0].Amount != NewMoney(2500, "USD") || order.Refunds[0].OrderID != order.ID {
+		t.Errorf("Expected a refund for the refund total, got %+v", order.Refunds)
+	}
+}
+
+func TestParseTransactions_Refund(t *testing.T) {
+	transactions, err := NewParser().ParseTransactions(strings.NewReader(testRefundTransactionsPage))
+	if err != nil {
+		t.Fatalf("ParseTransactions failed: %v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(transactions))
+	}
+
+	if transactions[0].Amount != NewMoney(-1999, "USD") || transactions[0].Status != "Refunded" {
+		t.Errorf("Expected refund of -19.99, got %s (%s)", transactions[0].Amount, transactions[0].Status)
+	}
+	if transactions[1].Amount != NewMoney(2999, "USD") {
+		t.Errorf("Expected charge of 29.99, got %s", transactions[1].Amount)
+	}
+}
+
+func TestParseTransactions_NonRefundableCharge(t *testing.T) {
+	page := `<html><body>
+<div class="apx-transactions-line-item-component-container">
+	<div data-pmts-component-id="row">
+		<div class="a-column a-span9"><span class="a-text-bold">Prime Visa ****1211</span></div>
+		<div class="a-column a-span3"><span class="a-text-bold">-$50.00</span></div>
+		<div class="a-column a-span12"><a href="/your-orders/order-details?orderID=114-9733092-9360267">Order #114-9733092-9360267</a></div>
+		<div class="a-column a-span12"><span class="a-size-base">Gift card, non-refundable. See refund policy.</span></div>
+	</div>
+</div>
+</body></html>`
+
+	transactions, err := NewParser().ParseTransactions(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("ParseTransactions failed: %v", err)
+	}
+	if len(transactions) != 1 {
+		t.Fatalf("Expected 1 transaction, got %d", len(transactions))
+	}
+	if transactions[0].Amount != NewMoney(5000, "USD") || transactions[0].Status == "Refunded" {
+		t.Errorf("Expected charge of 50.00, got %s (%s)", transactions[0].Amount, transactions[0].Status)
+	}
+}
+
+func TestWithRefundTransactions(t *testing.T) {
+	charge := &Transaction{OrderID: "114-9733092-9360267", Amount: NewMoney(2999, "USD")}
+	listed := &Transaction{OrderID: "114-9733092-9360267", Amount: NewMoney(-1999, "USD"), Status: "Refunded"}
+	refunds := []*Refund{
+		{OrderID: "114-9733092-9360267", Amount: NewMoney(1999, "USD")},
+		{OrderID: "114-9733092-9360267", Amount: NewMoney(500, "USD")},
+		{OrderID: "114-9733092-9360267", Amount: NewMoney(0, "USD"), Status: "Return started"},
+	}
+
+	transactions := withRefundTransactions([]*Transaction{charge, listed}, refunds)
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
+	}
+	added := transactions[2]
+	if added.Amount != NewMoney(-500, "USD") || added.Status != "Refunded" || added.OrderID != "114-9733092-9360267" {
+		t.Errorf("Unexpected refund transaction: %+v", added)
+	}
+}
+
+func TestWithRefundTransactions_EqualRefunds(t *testing.T) {
+	jan10 := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	listed := &Transaction{OrderID: "114-9733092-9360267", Date: jan10, Amount: NewMoney(-1999, "USD"), Status: "Refunded"}
+	refunds := []*Refund{
+		{OrderID: "114-9733092-9360267", Date: jan10, Amount: NewMoney(1999, "USD")},
+		{OrderID: "114-9733092-9360267", Date: jan10.AddDate(0, 1, 0), Amount: NewMoney(1999, "USD")},
+		{OrderID: "114-9733092-9360267", Amount: NewMoney(1999, "GBP")},
+	}
+
+	transactions := withRefundTransactions([]*Transaction{listed}, refunds)
+	if len(transactions) != 3 {
+		t.Fatalf("Expected the second and third refunds to be added, got %d transactions", len(transactions))
+	}
+	if tx := transactions[1]; tx.Amount != NewMoney(-1999, "USD") || !tx.Date.Equal(jan10.AddDate(0, 1, 0)) {
+		t.Errorf("Unexpected second refund transaction: %+v", tx)
+	}
+	if tx := transactions[2]; tx.Amount != NewMoney(-1999, "GBP") {
+		t.Errorf("Unexpected third refund transaction: %+v", tx)
+	}
+
+	// Two equal refunds on the same day need two listed transactions
+	refunds = []*Refund{
+		{OrderID: "114-9733092-9360267", Date: jan10, Amount: NewMoney(1999, "USD")},
+		{OrderID: "114-9733092-9360267", Date: jan10, Amount: NewMoney(1999, "USD")},
+	}
+	transactions = withRefundTransactions([]*Transaction{listed}, refunds)
+	if len(transactions) != 2 {
+		t.Errorf("Expected one refund to be added, got %d transactions", len(transactions))
+	}
+}
+
+func TestClient_FetchRefunds(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(returnsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testReturnsPage))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	refunds, err := client.FetchRefunds(context.Background())
+	if err != nil {
+		t.Fatalf("FetchRefunds failed: %v", err)
+	}
+	if len(refunds) != 2 {
+		t.Fatalf("Expected 2 refunds, got %d", len(refunds))
+	}
+
+	issued := refunds[0]
+	if issued.OrderID != "114-9733092-9360267" || issued.Status != "Refund issued" {
+		t.Errorf("Unexpected refund: order=%s status=%q", issued.OrderID, issued.Status)
+	}
+	if issued.Amount != NewMoney(1999, "USD") || issued.Date.Format("2006-01-02") != "2025-01-10" {
+		t.Errorf("Unexpected refund amount/date: %s %v", issued.Amount, issued.Date)
+	}
+	if len(issued.Items) != 1 || issued.Items[0].ASIN != "B09XV8WDY6" {
+		t.Errorf("Unexpected refund items: %+v", issued.Items)
+	}
+
+	pending := refunds[1]
+	if pending.OrderID != "113-7382612-3141857" || !pending.Amount.IsZero() || !pending.Date.IsZero() {
+		t.Errorf("Expected pending return without amount or date, got %s %v", pending.Amount, pending.Date)
+	}
+}
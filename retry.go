@@ -0,0 +1,169 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay  = 1 * time.Second
+	defaultRetryMaxDelay   = 30 * time.Second
+	defaultRetryMaxElapsed = 2 * time.Minute
+)
+
+// RetryAttempt describes a failed request for a RetryPolicy
+type RetryAttempt struct {
+	Attempt  int            // Number of attempts made so far, starting at 1
+	Elapsed  time.Duration  // Time since the first attempt started
+	Request  *http.Request  // The request that failed
+	Response *http.Response // The response, or nil if the request failed in transport
+	Err      error          // The transport error, or nil if a response was received
+}
+
+// RetryPolicy decides whether a failed request is retried and how long to
+// wait first. It is called for transport errors and for responses with
+// status 400 or above, except 401 and 403 which always fail immediately.
+type RetryPolicy interface {
+	NextRetry(attempt RetryAttempt) (delay time.Duration, retry bool)
+}
+
+// ExponentialBackoff retries 429 responses, and 5xx responses and transport
+// errors on idempotent requests, doubling the delay each attempt with jitter.
+// A Retry-After header on the response takes precedence over the computed delay.
+type ExponentialBackoff struct {
+	MaxRetries int           // Maximum retries after the first attempt
+	BaseDelay  time.Duration // Delay before the first retry, before jitter
+	MaxDelay   time.Duration // Upper bound on a single computed delay
+	MaxElapsed time.Duration // Give up once retrying would exceed this total time (0 = no limit)
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy(maxRetries int) *ExponentialBackoff {
+	return &ExponentialBackoff{
+		MaxRetries: maxRetries,
+		BaseDelay:  defaultRetryBaseDelay,
+		MaxDelay:   defaultRetryMaxDelay,
+		MaxElapsed: defaultRetryMaxElapsed,
+	}
+}
+
+// NextRetry implements RetryPolicy
+func (b *ExponentialBackoff) NextRetry(a RetryAttempt) (time.Duration, bool) {
+	if a.Attempt > b.MaxRetries || !isRetryable(a) {
+		return 0, false
+	}
+
+	delay, ok := retryAfter(a.Response)
+	if !ok {
+		delay = b.backoff(a.Attempt)
+	}
+
+	if b.MaxElapsed > 0 && a.Elapsed+delay > b.MaxElapsed {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// backoff returns the jittered exponential delay before retry number attempt
+func (b *ExponentialBackoff) backoff(attempt int) time.Duration {
+	delay := float64(b.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if b.MaxDelay > 0 && delay > float64(b.MaxDelay) {
+		delay = float64(b.MaxDelay)
+	}
+
+	// Equal jitter: keep half the delay and randomize the rest so clients
+	// that failed together don't retry together
+	half := delay / 2
+	return time.Duration(half + rand.Float64()*half)
+}
+
+// isRetryable reports whether a failed attempt is worth retrying
+func isRetryable(a RetryAttempt) bool {
+	if a.Err != nil {
+		// Cancelled or timed out by the caller; retrying won't help
+		if errors.Is(a.Err, context.Canceled) || errors.Is(a.Err, context.DeadlineExceeded) {
+			return false
+		}
+		// The request may have reached Amazon, so only resend if that is safe
+		return isIdempotent(a.Request)
+	}
+
+	if a.Response == nil {
+		return false
+	}
+
+	switch a.Response.StatusCode {
+	case http.StatusTooManyRequests:
+		// Rejected before it was processed, so always safe to resend
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		// The server may have acted on the request before failing
+		return isIdempotent(a.Request)
+	}
+	return false
+}
+
+// isIdempotent reports whether a request can safely be sent more than once
+func isIdempotent(req *http.Request) bool {
+	if req == nil {
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryAfter parses a response's Retry-After header, in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+package amazon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected time.Duration
+		ok       bool
+	}{
+		{"seconds", "5", 5 * time.Second, true},
+		{"zero", "0", 0, true},
+		{"past date", "Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+		{"missing", "", 0, false},
+		{"invalid", "soon", 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+			delay, ok := retryAfter(resp)
+			if ok != tc.ok || delay != tc.expected {
+				t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tc.header, delay, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat))
+	if delay, ok := retryAfter(resp); !ok || delay <= 0 || delay > 10*time.Second {
+		t.Errorf("Expected a delay of up to 10s for a future date, got %v, %v", delay, ok)
+	}
+}
+
+func TestExponentialBackoff_NextRetry(t *testing.T) {
+	policy := &ExponentialBackoff{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	get, _ := http.NewRequest(http.MethodGet, MarketplaceUS.BaseURL()+ordersPath, nil)
+	post, _ := http.NewRequest(http.MethodPost, MarketplaceUS.BaseURL()+ordersPath, nil)
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	notFound := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	transportErr := errors.New("connection reset")
+
+	// Delays double with equal jitter and are capped at MaxDelay
+	bounds := []struct{ min, max time.Duration }{
+		{50 * time.Millisecond, 100 * time.Millisecond},
+		{100 * time.Millisecond, 200 * time.Millisecond},
+		{150 * time.Millisecond, 300 * time.Millisecond},
+	}
+	for i, b := range bounds {
+		delay, retry := policy.NextRetry(RetryAttempt{Attempt: i + 1, Request: get, Response: unavailable})
+		if !retry || delay < b.min || delay > b.max {
+			t.Errorf("Attempt %d: got %v, %v; want delay in [%v, %v]", i+1, delay, retry, b.min, b.max)
+		}
+	}
+
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 4, Request: get, Response: unavailable}); retry {
+		t.Error("Expected no retry after MaxRetries")
+	}
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: get, Response: notFound}); retry {
+		t.Error("Expected no retry for 404")
+	}
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: get, Err: transportErr}); !retry {
+		t.Error("Expected GET transport error to be retried")
+	}
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: post, Err: transportErr}); retry {
+		t.Error("Expected POST transport error not to be retried")
+	}
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: post, Response: unavailable}); retry {
+		t.Error("Expected POST 503 not to be retried")
+	}
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: get, Err: context.Canceled}); retry {
+		t.Error("Expected cancelled request not to be retried")
+	}
+
+	limited := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	limited.Header.Set("Retry-After", "2")
+	if delay, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: get, Response: limited}); !retry || delay != 2*time.Second {
+		t.Errorf("Expected Retry-After delay of 2s, got %v, %v", delay, retry)
+	}
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Request: post, Response: limited}); !retry {
+		t.Error("Expected POST 429 to be retried")
+	}
+
+	policy.MaxElapsed = time.Second
+	if _, retry := policy.NextRetry(RetryAttempt{Attempt: 1, Elapsed: 900 * time.Millisecond, Request: get, Response: limited}); retry {
+		t.Error("Expected no retry past MaxElapsed")
+	}
+}
+
+func newRetryTestClient(t *testing.T, policy RetryPolicy) *Client {
+	t.Helper()
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithRateLimit(0),
+		WithRetryPolicy(policy),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+func TestDoRequest_RetriesThenSucceeds(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, DefaultRetryPolicy(3))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	resp, err := client.doRequest(req)
+	if err != nil {
+		t.Fatalf("doRequest failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoRequest_GivesUp(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, &ExponentialBackoff{MaxRetries: 2, BaseDelay: time.Millisecond})
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	if _, err := client.doRequest(req); err == nil {
+		t.Fatal("Expected an error after retries were exhausted")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoRequest_NoRetryOnNotFound(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, DefaultRetryPolicy(3))
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	resp, err := client.doRequest(req)
+	if err != nil {
+		t.Fatalf("doRequest failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound || calls != 1 {
+		t.Errorf("Expected a single 404, got status %d after %d attempts", resp.StatusCode, calls)
+	}
+}
+
+func TestDoRequest_CancelDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, &ExponentialBackoff{MaxRetries: 3})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	start := time.Now()
+	_, err := client.doRequest(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to interrupt the backoff, took %v", elapsed)
+	}
+}
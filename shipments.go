@@ -0,0 +1,320 @@
+package amazon
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+var (
+	// "January 5", "Jan. 5, 2025"
+	monthDayPattern = regexp.MustCompile(`(?i)\b(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+(\d{1,2})\b(?:,?\s+(\d{4})\b)?`)
+	// "5 January", "5. Januar 2025"
+	dayMonthPattern = regexp.MustCompile(`(?i)\b(\d{1,2})\.?\s+(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?(?:\s+(\d{4})\b)?`)
+
+	carrierPattern        = regexp.MustCompile(`(?i)^(?:shipped with|delivered by|delivery by|carrier:?)\s+(.+?)(?:\s+tracking\b.*)?$`)
+	trackingNumberPattern = regexp.MustCompile(`(?i)tracking (?:id|number|#)\s*:?\s*([A-Z0-9][A-Z0-9-]{5,})`)
+)
+
+var monthAbbrevs = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// shipmentState normalizes a shipment status line like "Arriving tomorrow".
+// Order matters: "Not yet shipped" is not in transit, "Return complete" is not delivered.
+func shipmentState(status string) ShipmentState {
+	s := strings.ToLower(status)
+
+	switch {
+	case strings.Contains(s, "cancel"):
+		return ShipmentCancelled
+	case strings.Contains(s, "return") || strings.Contains(s, "refund"):
+		return ShipmentReturned
+	case strings.Contains(s, "not yet shipped") || strings.Contains(s, "not shipped") || strings.Contains(s, "preparing"):
+		return ShipmentNotShipped
+	case strings.Contains(s, "delivered") && !strings.Contains(s, "not delivered"):
+		return ShipmentDelivered
+	case strings.Contains(s, "arriving") || strings.Contains(s, "shipped") || strings.Contains(s, "out for delivery") ||
+		strings.Contains(s, "on the way") || strings.Contains(s, "in transit") || strings.Contains(s, "expected") ||
+		strings.Contains(s, "running late") || strings.Contains(s, "delayed") || strings.Contains(s, "not delivered"):
+		return ShipmentInTransit
+	}
+	return ShipmentUnknown
+}
+
+// parseShipmentStatus reads the state and date from a shipment status line
+// like "Delivered January 5" or "Arriving Saturday". orderDate, if known,
+// is used to fill in the year Amazon leaves out.
+func (p *Parser) parseShipmentStatus(status string, orderDate time.Time) (ShipmentState, time.Time) {
+	state := shipmentState(status)
+	past := state == ShipmentDelivered || state == ShipmentReturned || state == ShipmentCancelled
+	return state, p.statusDate(status, orderDate, past)
+}
+
+// statusDate extracts the date from a status line. Weekdays are resolved
+// backwards from today for past events and forwards otherwise.
+func (p *Parser) statusDate(status string, orderDate time.Time, past bool) time.Time {
+	now := p.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Translate localized month names and find relative days
+	words := strings.Fields(strings.ToLower(status))
+	for i, word := range words {
+		word = strings.Trim(word, ",.")
+		if english, ok := p.marketplace.MonthNames[word]; ok {
+			words[i] = english
+			continue
+		}
+		switch word {
+		case "today":
+			return today
+		case "tomorrow":
+			return today.AddDate(0, 0, 1)
+		case "yesterday":
+			return today.AddDate(0, 0, -1)
+		}
+	}
+	text := strings.Join(words, " ")
+
+	var monthText, dayText, yearText string
+	if m := monthDayPattern.FindStringSubmatch(text); m != nil {
+		monthText, dayText, yearText = m[1], m[2], m[3]
+	} else if m := dayMonthPattern.FindStringSubmatch(text); m != nil {
+		dayText, monthText, yearText = m[1], m[2], m[3]
+	} else {
+		for _, word := range words {
+			word = strings.Trim(word, ",.")
+			for wd := time.Sunday; wd <= time.Saturday; wd++ {
+				if word != strings.ToLower(wd.String()) {
+					continue
+				}
+				if past {
+					return today.AddDate(0, 0, -((int(today.Weekday()) - int(wd) + 7) % 7))
+				}
+				return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7)
+			}
+		}
+		return time.Time{}
+	}
+
+	month := monthAbbrevs[strings.ToLower(monthText)[:3]]
+	day, _ := strconv.Atoi(dayText)
+	if yearText != "" {
+		year, _ := strconv.Atoi(yearText)
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	return inferYear(month, day, orderDate, today)
+}
+
+// inferYear picks the year for a date shown without one: the first on or
+// after the order date if known, otherwise the one nearest today
+func inferYear(month time.Month, day int, orderDate, today time.Time) time.Time {
+	if !orderDate.IsZero() {
+		t := time.Date(orderDate.Year(), month, day, 0, 0, 0, 0, time.UTC)
+		if t.Before(orderDate.AddDate(0, 0, -1)) {
+			t = t.AddDate(1, 0, 0)
+		}
+		return t
+	}
+
+	t := time.Date(today.Year(), month, day, 0, 0, 0, 0, time.UTC)
+	halfYear := 183 * 24 * time.Hour
+	switch {
+	case t.Sub(today) > halfYear:
+		t = t.AddDate(-1, 0, 0)
+	case today.Sub(t) > halfYear:
+		t = t.AddDate(1, 0, 0)
+	}
+	return t
+}
+
+// anchorShipmentDates re-infers the year of shipment dates that don't fall
+// within a year after the order date, for details parsed before the order
+// date was known
+func (o *Order) anchorShipmentDates() {
+	if o.Date.IsZero() {
+		return
+	}
+	for _, shipment := range o.Shipments {
+		shipment.DeliveredDate = anchorDate(shipment.DeliveredDate, o.Date)
+		shipment.PromisedDate = anchorDate(shipment.PromisedDate, o.Date)
+	}
+}
+
+// anchorDate moves t into the year after orderDate if it falls outside it
+func anchorDate(t, orderDate time.Time) time.Time {
+	if t.IsZero() || (!t.Before(orderDate.AddDate(0, 0, -1)) && !t.After(orderDate.AddDate(1, 0, 0))) {
+		return t
+	}
+	return inferYear(t.Month(), t.Day(), orderDate, time.Time{})
+}
+
+// parseShipments groups the order's items by the shipment box they appear
+// in, with each box's delivery status and tracking link
+func (p *Parser) parseShipments(doc *goquery.Document, order *Order) {
+	itemsByASIN := make(map[string]*OrderItem, len(order.Items))
+	for _, item := range order.Items {
+		itemsByASIN[item.ASIN] = item
+	}
+
+	grids := doc.Find("[data-component='shipmentsLeftGrid']")
+	if grids.Length() == 0 {
+		grids = doc.Find("[data-component='shipments']")
+	}
+
+	grids.Each(func(i int, grid *goquery.Selection) {
+		shipment := &Shipment{}
+		seen := make(map[string]bool)
+		grid.Find("a[href*='/dp/']").Each(func(j int, link *goquery.Selection) {
+			asin := extractASINFromURL(link.AttrOr("href", ""))
+			if item := itemsByASIN[asin]; item != nil && !seen[asin] {
+				seen[asin] = true
+				shipment.Items = append(shipment.Items, item)
+			}
+		})
+		if len(shipment.Items) == 0 {
+			return
+		}
+
+		box := shipmentBox(grid)
+		shipment.Status = shipmentStatusText(box)
+
+		var date time.Time
+		shipment.State, date = p.parseShipmentStatus(shipment.Status, order.Date)
+		switch shipment.State {
+		case ShipmentDelivered:
+			shipment.DeliveredDate = date
+		case ShipmentInTransit, ShipmentNotShipped:
+			shipment.PromisedDate = date
+		case ShipmentReturned:
+			order.Refunds = append(order.Refunds, p.parseReturnedShipment(box, order, shipment))
+		}
+
+		trackLink := box.Find("a[href*='ship-track'], a[href*='progress-tracker']").First()
+		if href, exists := trackLink.Attr("href"); exists {
+			shipment.TrackingURL = p.pageURL(href)
+		}
+
+		order.Shipments = append(order.Shipments, shipment)
+	})
+}
+
+// shipmentBox returns the card holding one shipment's status, items and
+// tracking button. If the nearest box holds several shipments, the grid's
+// parent row is used instead.
+func shipmentBox(grid *goquery.Selection) *goquery.Selection {
+	if grid.AttrOr("data-component", "") == "shipments" {
+		return grid
+	}
+	box := grid.ParentsFiltered(".a-box").First()
+	if box.Length() == 0 || box.Find("[data-component='shipmentsLeftGrid']").Length() > 1 {
+		return grid.Parent()
+	}
+	return box
+}
+
+// shipmentStatusText returns a shipment's primary status line, without
+// secondary text like "Your package was left near the front door"
+func shipmentStatusText(box *goquery.Selection) string {
+	status := box.Find(".yohtmlc-shipment-status-primaryText, .od-status-message").First()
+	if status.Length() == 0 {
+		status = box.Find("[data-component='shipmentStatus']").First()
+		if bold := status.Find(".a-text-bold").First(); bold.Length() > 0 {
+			status = bold
+		}
+	}
+	return strings.Join(strings.Fields(status.Text()), " ")
+}
+
+// ParseTracking parses a shipment's tracking page for the carrier, tracking
+// number and current status
+func (p *Parser) ParseTracking(r io.Reader) (*Tracking, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeTracking, Err: err}
+	}
+
+	tracking := &Tracking{
+		Status: strings.Join(strings.Fields(doc.Find("#primaryStatus, .pt-status-main-status").First().Text()), " "),
+	}
+
+	// Labels vary between the old and new tracking pages, so match on text.
+	// Later (innermost) matches win over the containers that enclose them.
+	// Element texts are gathered in one pass, since calling Text() on every
+	// element walks each subtree again.
+	texts := make(map[*html.Node]string)
+	for _, n := range doc.Find("body").Nodes {
+		collectShortTexts(n, texts)
+	}
+	doc.Find("body *").Each(func(i int, s *goquery.Selection) {
+		raw, ok := texts[s.Nodes[0]]
+		if !ok {
+			return
+		}
+		text := strings.Join(strings.Fields(raw), " ")
+		if text == "" || len(text) > 120 {
+			return
+		}
+		if m := carrierPattern.FindStringSubmatch(text); m != nil {
+			tracking.Carrier = m[1]
+		}
+		if m := trackingNumberPattern.FindStringSubmatch(text); m != nil {
+			tracking.TrackingNumber = m[1]
+		}
+	})
+
+	if tracking.Carrier == "" && tracking.TrackingNumber == "" && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeTracking, Err: ErrEncryptedContent}
+	}
+
+	return tracking, nil
+}
+
+// maxShortText bounds the raw text kept for an element by collectShortTexts.
+// Tracking labels are at most 120 characters once whitespace is collapsed,
+// so elements with more text than this are containers.
+const maxShortText = 1024
+
+// collectShortTexts records the text of n and each element below it in texts,
+// leaving out elements whose text is longer than maxShortText. It reports n's
+// text and whether it was short enough to keep.
+func collectShortTexts(n *html.Node, texts map[*html.Node]string) (string, bool) {
+	var b strings.Builder
+	short := true
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			if short {
+				b.WriteString(c.Data)
+			}
+		case html.ElementNode:
+			// Children are always walked so their own texts are recorded
+			if text, ok := collectShortTexts(c, texts); ok && short {
+				b.WriteString(text)
+			} else {
+				short = false
+			}
+		}
+		if b.Len() > maxShortText {
+			short = false
+		}
+	}
+
+	if !short {
+		return "", false
+	}
+	texts[n] = b.String()
+	return b.String(), true
+}
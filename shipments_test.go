@@ -0,0 +1,220 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMultiShipmentPage = `<html><body>
+<span>Order # 114-9733092-9360267</span>
+<div data-component="shipments">
+	<div class="a-box-group">
+		<div class="a-box shipment">
+			<div data-component="shipmentStatus"><span class="a-text-bold">Delivered January 5</span><span>Your package was left near the front door</span></div>
+			<div data-component="shipmentsLeftGrid">
+				<a href="/dp/B09XV8WDY6"><img src="cable.jpg"></a>
+				<a href="/dp/B09XV8WDY6">USB-C Charging Cable</a>
+				<div data-component="unitPrice"><span class="a-offscreen">$19.99</span></div>
+				<a href="/dp/B0FJDMHXD1">Dish Soap, 3 Pack</a>
+				<div data-component="unitPrice"><span class="a-offscreen">$10.00</span></div>
+			</div>
+			<a href="/gp/your-account/ship-track?orderId=114-9733092-9360267&shipmentId=Abc123">Track package</a>
+		</div>
+		<div class="a-box shipment">
+			<div data-component="shipmentStatus"><span class="a-text-bold">Arriving tomorrow</span></div>
+			<div data-component="shipmentsLeftGrid">
+				<a href="/dp/B0D6VC4PM6">Laptop Stand</a>
+				<div data-component="unitPrice"><span class="a-offscreen">$35.00</span></div>
+			</div>
+			<a href="https://www.amazon.com/gp/your-account/ship-track?orderId=114-9733092-9360267&shipmentId=Def456">Track package</a>
+		</div>
+	</div>
+</div>
+</body></html>`
+
+const testTrackingPage = `<html><body>
+<div id="primaryStatus">Delivered</div>
+<div class="carrierRelatedInfo-mfn-providerTitle">Shipped with UPS</div>
+<div class="pt-delivery-card-trackingId">Tracking ID: 1Z999AA10123456784</div>
+</body></html>`
+
+func TestParseOrderDetails_Shipments(t *testing.T) {
+	parser := NewParser()
+	parser.now = func() time.Time { return time.Date(2025, time.January, 7, 15, 0, 0, 0, time.UTC) }
+
+	order, err := parser.ParseOrderDetails(strings.NewReader(testMultiShipmentPage))
+	if err != nil {
+		t.Fatalf("ParseOrderDetails failed: %v", err)
+	}
+
+	if len(order.Items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(order.Items))
+	}
+	if len(order.Shipments) != 2 {
+		t.Fatalf("Expected 2 shipments, got %d", len(order.Shipments))
+	}
+
+	delivered := order.Shipments[0]
+	if delivered.Status != "Delivered January 5" || delivered.State != ShipmentDelivered {
+		t.Errorf("Unexpected first shipment status %q (%s)", delivered.Status, delivered.State)
+	}
+	if got := delivered.DeliveredDate.Format("2006-01-02"); got != "2025-01-05" {
+		t.Errorf("Expected delivered date 2025-01-05, got %s", got)
+	}
+	if len(delivered.Items) != 2 || delivered.Items[0] != order.Items[0] || delivered.Items[1] != order.Items[1] {
+		t.Errorf("Expected first shipment to share the order's first two items")
+	}
+	if delivered.TrackingURL != "https://www.amazon.com/gp/your-account/ship-track?orderId=114-9733092-9360267&shipmentId=Abc123" {
+		t.Errorf("Unexpected tracking URL: %s", delivered.TrackingURL)
+	}
+
+	arriving := order.Shipments[1]
+	if arriving.State != ShipmentInTransit {
+		t.Errorf("Expected in_transit, got %s", arriving.State)
+	}
+	if got := arriving.PromisedDate.Format("2006-01-02"); got != "2025-01-08" {
+		t.Errorf("Expected promised date 2025-01-08, got %s", got)
+	}
+	if len(arriving.Items) != 1 || arriving.Items[0].ASIN != "B0D6VC4PM6" {
+		t.Errorf("Unexpected second shipment items: %+v", arriving.Items)
+	}
+}
+
+func TestParseShipmentStatus(t *testing.T) {
+	parser := NewParser()
+	// Wednesday
+	parser.now = func() time.Time { return time.Date(2025, time.January, 8, 9, 0, 0, 0, time.UTC) }
+	orderDate := time.Date(2024, time.December, 28, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		status    string
+		orderDate time.Time
+		wantState ShipmentState
+		wantDate  string
+	}{
+		{"Delivered January 5", orderDate, ShipmentDelivered, "2025-01-05"},
+		{"Delivered Dec 30", orderDate, ShipmentDelivered, "2024-12-30"},
+		{"Delivered 30 December", time.Time{}, ShipmentDelivered, "2024-12-30"},
+		{"Delivered Nov 2, 2023", time.Time{}, ShipmentDelivered, "2023-11-02"},
+		{"Delivered Monday", orderDate, ShipmentDelivered, "2025-01-06"},
+		{"Arriving Saturday", orderDate, ShipmentInTransit, "2025-01-11"},
+		{"Arriving today by 10 PM", orderDate, ShipmentInTransit, "2025-01-08"},
+		{"Now expected Jan 10", orderDate, ShipmentInTransit, "2025-01-10"},
+		{"Out for delivery", orderDate, ShipmentInTransit, ""},
+		{"Not yet shipped", orderDate, ShipmentNotShipped, ""},
+		{"Cancelled", orderDate, ShipmentCancelled, ""},
+		{"Return complete", orderDate, ShipmentReturned, ""},
+		{"Something else", orderDate, ShipmentUnknown, ""},
+	}
+
+	for _, tc := range tests {
+		state, date := parser.parseShipmentStatus(tc.status, tc.orderDate)
+		if state != tc.wantState {
+			t.Errorf("parseShipmentStatus(%q) state = %q, want %q", tc.status, state, tc.wantState)
+		}
+		got := ""
+		if !date.IsZero() {
+			got = date.Format("2006-01-02")
+		}
+		if got != tc.wantDate {
+			t.Errorf("parseShipmentStatus(%q) date = %q, want %q", tc.status, got, tc.wantDate)
+		}
+	}
+}
+
+func TestMergeOrderSummary_AnchorsShipmentDates(t *testing.T) {
+	// Parsed in 2025 without an order date, so the year was guessed from today
+	order := &Order{Shipments: []*Shipment{
+		{State: ShipmentDelivered, DeliveredDate: time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)},
+	}}
+	summary := &OrderSummary{Date: time.Date(2022, time.February, 27, 0, 0, 0, 0, time.UTC)}
+
+	mergeOrderSummary(order, summary)
+	if got := order.Shipments[0].DeliveredDate.Format("2006-01-02"); got != "2022-03-03" {
+		t.Errorf("Expected delivered date 2022-03-03, got %s", got)
+	}
+}
+
+func TestParseTracking(t *testing.T) {
+	tracking, err := NewParser().ParseTracking(strings.NewReader(testTrackingPage))
+	if err != nil {
+		t.Fatalf("ParseTracking failed: %v", err)
+	}
+	if tracking.Carrier != "UPS" {
+		t.Errorf("Expected carrier UPS, got %q", tracking.Carrier)
+	}
+	if tracking.TrackingNumber != "1Z999AA10123456784" {
+		t.Errorf("Expected tracking number 1Z999AA10123456784, got %q", tracking.TrackingNumber)
+	}
+	if tracking.Status != "Delivered" {
+		t.Errorf("Expected status Delivered, got %q", tracking.Status)
+	}
+}
+
+func TestParseTracking_LargePage(t *testing.T) {
+	// Labels nested deep inside a page with many other elements
+	var b strings.Builder
+	b.WriteString("<html><body><div id=\"primaryStatus\">In transit</div>")
+	for i := 0; i < 200; i++ {
+		b.WriteString("<div>")
+	}
+	b.WriteString(`<div class="tracking"><span>Delivery by</span> <span>USPS</span></div>`)
+	b.WriteString(`<div><span>Tracking ID:</span> <span>9400111899223197428490</span></div>`)
+	for i := 0; i < 5000; i++ {
+		b.WriteString("<p>Recommended for you</p>")
+	}
+	for i := 0; i < 200; i++ {
+		b.WriteString("</div>")
+	}
+	b.WriteString("</body></html>")
+
+	tracking, err := NewParser().ParseTracking(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ParseTracking failed: %v", err)
+	}
+	if tracking.Carrier != "USPS" || tracking.TrackingNumber != "9400111899223197428490" {
+		t.Errorf("Expected USPS 9400111899223197428490, got %+v", tracking)
+	}
+}
+
+func TestClient_FetchTracking(t *testing.T) {
+	var gotQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gp/your-account/ship-track", func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(testTrackingPage))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	shipment := &Shipment{TrackingURL: "https://www.amazon.com/gp/your-account/ship-track?orderId=114-9733092-9360267&shipmentId=Abc123"}
+	tracking, err := client.FetchTracking(context.Background(), shipment)
+	if err != nil {
+		t.Fatalf("FetchTracking failed: %v", err)
+	}
+	if gotQuery != "orderId=114-9733092-9360267&shipmentId=Abc123" {
+		t.Errorf("Unexpected tracking query: %s", gotQuery)
+	}
+	if shipment.Tracking != tracking || tracking.Carrier != "UPS" {
+		t.Errorf("Expected shipment tracking to be set, got %+v", shipment.Tracking)
+	}
+
+	if _, err := client.FetchTracking(context.Background(), &Shipment{}); err == nil {
+		t.Error("Expected error for shipment without tracking link")
+	}
+}
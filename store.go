@@ -0,0 +1,289 @@
+package amazon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultOrderStoreFile = "orders.json"
+
+// OrderStore is a local, file-based database of orders and transactions.
+// It records when the account was last synced so Sync can fetch incrementally.
+type OrderStore struct {
+	orders       map[string]*Order
+	transactions map[string][]*Transaction
+	listTotals   map[string]Money
+	lastSync     time.Time
+	filePath     string
+	mu           sync.RWMutex
+	saveMu       sync.Mutex // Serializes writes to filePath and its temp file
+}
+
+// OrderStoreFile represents the JSON structure for order storage
+type OrderStoreFile struct {
+	Orders       []*Order         `json:"orders"`
+	Transactions []*Transaction   `json:"transactions"`
+	ListTotals   map[string]Money `json:"list_totals,omitempty"` // Order totals last seen on the order list page
+	LastSync     time.Time        `json:"last_sync"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// OrderQuery selects orders from an OrderStore. Zero fields match everything.
+type OrderQuery struct {
+	StartDate time.Time
+	EndDate   time.Time
+	ASIN      string // Orders containing this item
+	CardType  string // Orders paid with this card type (e.g., "Visa")
+	LastFour  string // Orders paid with the card ending in these digits
+}
+
+// NewOrderStore creates an order store backed by the given file path
+func NewOrderStore(filePath string) (*OrderStore, error) {
+	store := &OrderStore{
+		orders:       make(map[string]*Order),
+		transactions: make(map[string][]*Transaction),
+		listTotals:   make(map[string]Money),
+		filePath:     filePath,
+	}
+
+	if err := store.Load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load orders: %w", err)
+	}
+
+	return store, nil
+}
+
+// DefaultOrderStorePath returns the default order store file path
+func DefaultOrderStorePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, defaultCookieDir, defaultOrderStoreFile), nil
+}
+
+// OrderStorePathForAccount returns an order store file path for a specific account
+// Example: OrderStorePathForAccount("personal") -> ~/.amazon-go/orders-personal.json
+func OrderStorePathForAccount(accountName string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	filename := fmt.Sprintf("orders-%s.json", accountName)
+	return filepath.Join(homeDir, defaultCookieDir, filename), nil
+}
+
+// Load reads orders from the file
+func (s *OrderStore) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return err
+	}
+
+	var storeFile OrderStoreFile
+	if err := json.Unmarshal(data, &storeFile); err != nil {
+		return fmt.Errorf("failed to parse orders: %w", err)
+	}
+
+	s.orders = make(map[string]*Order, len(storeFile.Orders))
+	for _, o := range storeFile.Orders {
+		s.orders[o.ID] = o
+	}
+
+	s.transactions = make(map[string][]*Transaction)
+	for _, tx := range storeFile.Transactions {
+		s.transactions[tx.OrderID] = append(s.transactions[tx.OrderID], tx)
+	}
+
+	s.listTotals = storeFile.ListTotals
+	if s.listTotals == nil {
+		s.listTotals = make(map[string]Money)
+	}
+
+	s.lastSync = storeFile.LastSync
+
+	return nil
+}
+
+// Save writes orders to the file. It is safe to call from several
+// goroutines, e.g. while Sync is saving.
+func (s *OrderStore) Save() error {
+	// Every save writes the same temp file, so overlapping saves could rename
+	// each other's file away
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	storeFile := OrderStoreFile{
+		Orders:     s.sortedOrders(),
+		ListTotals: s.listTotals,
+		LastSync:   s.lastSync,
+		UpdatedAt:  time.Now(),
+	}
+	for _, o := range storeFile.Orders {
+		storeFile.Transactions = append(storeFile.Transactions, s.transactions[o.ID]...)
+	}
+
+	data, err := json.MarshalIndent(storeFile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal orders: %w", err)
+	}
+
+	dir := filepath.Dir(s.filePath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create order store directory: %w", err)
+	}
+
+	// Write to a temp file first so a crash can't leave a truncated store
+	tmpPath := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write orders: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.filePath); err != nil {
+		return fmt.Errorf("failed to write orders: %w", err)
+	}
+
+	return nil
+}
+
+// Order returns an order by ID, or nil if it is not stored
+func (s *OrderStore) Order(orderID string) *Order {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.orders[orderID]
+}
+
+// PutOrder adds or replaces an order
+func (s *OrderStore) PutOrder(order *Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[order.ID] = order
+}
+
+// Transactions returns the stored transactions for an order
+func (s *OrderStore) Transactions(orderID string) []*Transaction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]*Transaction(nil), s.transactions[orderID]...)
+}
+
+// PutTransactions replaces the stored transactions for an order
+func (s *OrderStore) PutTransactions(orderID string, transactions []*Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions[orderID] = transactions
+}
+
+// listTotal returns the order total last seen on the order list page
+func (s *OrderStore) listTotal(orderID string) (Money, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	total, ok := s.listTotals[orderID]
+	return total, ok
+}
+
+// setListTotal records the order total shown on the order list page
+func (s *OrderStore) setListTotal(orderID string, total Money) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listTotals[orderID] = total
+}
+
+// Count returns the number of stored orders
+func (s *OrderStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.orders)
+}
+
+// LastSync returns when the store was last synced, or the zero time if never
+func (s *OrderStore) LastSync() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastSync
+}
+
+// SetLastSync records when the store was last synced
+func (s *OrderStore) SetLastSync(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSync = t
+}
+
+// Query returns the stored orders matching q, newest first
+func (s *OrderStore) Query(q OrderQuery) []*Order {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*Order
+	for _, o := range s.sortedOrders() {
+		if s.matches(o, q) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// matches checks whether an order satisfies every set field of the query
+func (s *OrderStore) matches(o *Order, q OrderQuery) bool {
+	if !q.StartDate.IsZero() && o.Date.Before(q.StartDate) {
+		return false
+	}
+	if !q.EndDate.IsZero() && o.Date.After(q.EndDate) {
+		return false
+	}
+
+	if q.ASIN != "" {
+		found := false
+		for _, item := range o.Items {
+			if item.ASIN == q.ASIN {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.CardType != "" || q.LastFour != "" {
+		found := false
+		for _, tx := range s.transactions[o.ID] {
+			if (q.CardType == "" || tx.CardType == q.CardType) &&
+				(q.LastFour == "" || tx.LastFour == q.LastFour) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortedOrders returns all orders newest first; the caller must hold the lock
+func (s *OrderStore) sortedOrders() []*Order {
+	orders := make([]*Order, 0, len(s.orders))
+	for _, o := range s.orders {
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].Date.Equal(orders[j].Date) {
+			return orders[i].ID < orders[j].ID
+		}
+		return orders[i].Date.After(orders[j].Date)
+	})
+	return orders
+}
@@ -0,0 +1,251 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOrderStore(t *testing.T) {
+	storeFile := filepath.Join(t.TempDir(), "orders.json")
+
+	store, err := NewOrderStore(storeFile)
+	if err != nil {
+		t.Fatalf("NewOrderStore failed: %v", err)
+	}
+
+	store.PutOrder(&Order{
+		ID:    "114-9733092-9360267",
+		Date:  time.Date(2025, 11, 26, 0, 0, 0, 0, time.UTC),
+		Total: NewMoney(4491, "USD"),
+		Items: []*OrderItem{{ASIN: "B09XV8WDY6", Name: "USB-C Cable"}},
+	})
+	store.PutOrder(&Order{
+		ID:    "113-7382612-3141857",
+		Date:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Total: NewMoney(1200, "USD"),
+		Items: []*OrderItem{{ASIN: "B0D6VC4PM6", Name: "Dish Soap"}},
+	})
+	store.PutTransactions("114-9733092-9360267", []*Transaction{
+		{OrderID: "114-9733092-9360267", Amount: NewMoney(4491, "USD"), CardType: "Visa", LastFour: "1211"},
+	})
+	syncTime := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	store.SetLastSync(syncTime)
+
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Reload from disk
+	store, err = NewOrderStore(storeFile)
+	if err != nil {
+		t.Fatalf("NewOrderStore (reload) failed: %v", err)
+	}
+
+	if store.Count() != 2 {
+		t.Errorf("Expected 2 orders, got %d", store.Count())
+	}
+	if !store.LastSync().Equal(syncTime) {
+		t.Errorf("Expected last sync %v, got %v", syncTime, store.LastSync())
+	}
+	if len(store.Transactions("114-9733092-9360267")) != 1 {
+		t.Errorf("Expected 1 transaction after reload")
+	}
+
+	tests := []struct {
+		name     string
+		query    OrderQuery
+		expected []string
+	}{
+		{"all newest first", OrderQuery{}, []string{"114-9733092-9360267", "113-7382612-3141857"}},
+		{"date range", OrderQuery{StartDate: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)}, []string{"114-9733092-9360267"}},
+		{"asin", OrderQuery{ASIN: "B0D6VC4PM6"}, []string{"113-7382612-3141857"}},
+		{"card", OrderQuery{CardType: "Visa", LastFour: "1211"}, []string{"114-9733092-9360267"}},
+		{"no match", OrderQuery{LastFour: "0000"}, nil},
+	}
+
+	for _, tc := range tests {
+		var ids []string
+		for _, o := range store.Query(tc.query) {
+			ids = append(ids, o.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("Query(%s) = %v, want %v", tc.name, ids, tc.expected)
+		}
+	}
+}
+
+func TestOrderStore_ConcurrentSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+	store, err := NewOrderStore(path)
+	if err != nil {
+		t.Fatalf("NewOrderStore failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			store.PutOrder(&Order{ID: fmt.Sprintf("order-%d", i)})
+			if err := store.Save(); err != nil {
+				t.Errorf("Save failed: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	loaded, err := NewOrderStore(path)
+	if err != nil {
+		t.Fatalf("Saved order store doesn't load: %v", err)
+	}
+	if loaded.Count() != 20 {
+		t.Errorf("Expected 20 orders, got %d", loaded.Count())
+	}
+}
+
+func TestSync(t *testing.T) {
+	pageDir := t.TempDir()
+	listURL := "https://www.amazon.com/your-orders/orders?timeFilter=year-2025"
+	detailsURL := MarketplaceUS.BaseURL() + orderDetailsPath + "?orderID=114-9733092-9360267"
+
+	writePage := func(pageURL, html string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(pageDir, PageFileName(pageURL)), []byte(html), 0600); err != nil {
+			t.Fatalf("failed to write page: %v", err)
+		}
+	}
+	writePage(listURL, testOrderListPage)
+	writePage(detailsURL, testOrderDetailsPage)
+
+	src, err := NewDirPageSource(pageDir)
+	if err != nil {
+		t.Fatalf("NewDirPageSource failed: %v", err)
+	}
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithPageSource(src),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	store, err := NewOrderStore(filepath.Join(t.TempDir(), "orders.json"))
+	if err != nil {
+		t.Fatalf("NewOrderStore failed: %v", err)
+	}
+
+	ctx := context.Background()
+	result, err := client.Sync(ctx, store, SyncOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.New != 1 || result.Unchanged != 0 {
+		t.Errorf("First sync: expected 1 new order, got %+v", result)
+	}
+	if store.LastSync().IsZero() {
+		t.Error("Expected last sync time to be recorded")
+	}
+	if order := store.Order("114-9733092-9360267"); order == nil || len(order.Items) != 1 {
+		t.Fatalf("Expected order with details to be stored, got %+v", order)
+	}
+
+	// Second sync skips orders whose total is unchanged
+	result, err = client.Sync(ctx, store, SyncOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Unchanged != 1 || result.New != 0 || result.Updated != 0 {
+		t.Errorf("Second sync: expected 1 unchanged order, got %+v", result)
+	}
+
+	// A changed total on the list page re-fetches details
+	writePage(listURL, strings.Replace(testOrderListPage, "$44.91", "$50.00", 1))
+	result, err = client.Sync(ctx, store, SyncOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Third sync: expected 1 updated order, got %+v", result)
+	}
+
+	// The new list total is remembered, so the next sync skips it again
+	result, err = client.Sync(ctx, store, SyncOptions{Year: 2025})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Unchanged != 1 {
+		t.Errorf("Fourth sync: expected 1 unchanged order, got %+v", result)
+	}
+}
+
+func TestSync_RetriesFailedDetails(t *testing.T) {
+	pageDir := t.TempDir()
+	src, err := NewDirPageSource(pageDir)
+	if err != nil {
+		t.Fatalf("NewDirPageSource failed: %v", err)
+	}
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithPageSource(src),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	writePage := func(pageURL, html string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(pageDir, PageFileName(pageURL)), []byte(html), 0600); err != nil {
+			t.Fatalf("failed to write page: %v", err)
+		}
+	}
+	// The order is from 2025; later years have empty lists
+	for year := 2025; year <= time.Now().Year(); year++ {
+		html := "<html><body></body></html>"
+		if year == 2025 {
+			html = testOrderListPage
+		}
+		writePage(client.buildOrderListURL(yearFilter(year), 0, OrderKindPhysical), html)
+	}
+
+	store, err := NewOrderStore(filepath.Join(t.TempDir(), "orders.json"))
+	if err != nil {
+		t.Fatalf("NewOrderStore failed: %v", err)
+	}
+	lastSync := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	store.SetLastSync(lastSync)
+
+	// The details page is missing, so the order fails
+	ctx := context.Background()
+	result, err := client.Sync(ctx, store, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Failed != 1 {
+		t.Fatalf("First sync: expected 1 failed order, got %+v", result)
+	}
+	if !store.LastSync().Equal(lastSync) {
+		t.Errorf("Expected last sync to stay %v after a failure, got %v", lastSync, store.LastSync())
+	}
+
+	// The order is more than syncLookback before now, but is still retried
+	writePage(MarketplaceUS.BaseURL()+orderDetailsPath+"?orderID=114-9733092-9360267", testOrderDetailsPage)
+	result, err = client.Sync(ctx, store, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.New != 1 || result.Failed != 0 {
+		t.Errorf("Second sync: expected 1 new order, got %+v", result)
+	}
+	if store.Order("114-9733092-9360267") == nil {
+		t.Error("Expected the failed order to be fetched by the next sync")
+	}
+	if !store.LastSync().After(lastSync) {
+		t.Error("Expected last sync to advance once nothing failed")
+	}
+}
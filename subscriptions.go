@@ -0,0 +1,266 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SubscriptionKind distinguishes Subscribe & Save deliveries from paid memberships
+type SubscriptionKind string
+
+// Subscription kinds
+const (
+	SubscriptionSubscribeSave SubscriptionKind = "subscribe_and_save" // Recurring product deliveries
+	SubscriptionMembership    SubscriptionKind = "membership"         // Prime, Kindle Unlimited, Audible and other paid plans
+)
+
+// Subscription is a recurring purchase: a Subscribe & Save item or a membership
+type Subscription struct {
+	ID              string           `json:"id"` // Amazon's subscription ID, or the ASIN if the page doesn't show one
+	Kind            SubscriptionKind `json:"kind"`
+	ASIN            string           `json:"asin,omitempty"`
+	Name            string           `json:"name"`
+	Quantity        float64          `json:"quantity,omitempty"`
+	Frequency       string           `json:"frequency"`        // As shown, e.g. "Every 2 months" or "Monthly"
+	NextDelivery    time.Time        `json:"next_delivery"`    // Next delivery, or next renewal for memberships
+	Price           Money            `json:"price"`            // Price per delivery or billing period, after discount
+	DiscountPercent float64          `json:"discount_percent"` // Subscribe & Save discount, e.g. 15
+}
+
+var (
+	frequencyPattern  = regexp.MustCompile(`(?i)every\s+(?:\d+\s+)?(?:days?|weeks?|months?|years?)`)
+	billingPattern    = regexp.MustCompile(`(?i)\b(monthly|annual|annually|yearly|weekly)\b|per\s+(?:month|year)|/\s*(?:mo|month|yr|year)\b`)
+	discountPattern   = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+	nextDatePattern   = regexp.MustCompile(`(?i)next delivery|arriving|arrives|ships|renews|renewal|next payment|next billing`)
+	qtyPattern        = regexp.MustCompile(`(?i)qty:?\s*(\d+)`)
+	subscriptionCards = "[data-subscription-id], .subscription-card"
+	membershipCards   = "[data-component='membershipCard'], .mys-subscription-card, .subscription-card"
+)
+
+// ParseSubscribeSave parses the Subscribe & Save management page and
+// returns one subscription per subscribed item
+func (p *Parser) ParseSubscribeSave(r io.Reader) ([]*Subscription, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeSubscriptions, Err: err}
+	}
+
+	var subscriptions []*Subscription
+	seen := make(map[string]bool)
+	doc.Find(subscriptionCards).Each(func(i int, card *goquery.Selection) {
+		// Cards nest buttons carrying the same subscription ID
+		if card.ParentsFiltered(subscriptionCards).Length() > 0 {
+			return
+		}
+
+		sub := &Subscription{
+			ID:       card.AttrOr("data-subscription-id", ""),
+			Kind:     SubscriptionSubscribeSave,
+			Quantity: 1,
+		}
+
+		card.Find(itemLinkSelector).EachWithBreak(func(j int, link *goquery.Selection) bool {
+			sub.ASIN = extractASINFromURL(link.AttrOr("href", ""))
+			sub.Name = strings.Join(strings.Fields(link.Text()), " ")
+			return sub.ASIN == "" || sub.Name == ""
+		})
+		if sub.ASIN == "" {
+			return
+		}
+		if sub.ID == "" {
+			sub.ID = sub.ASIN
+		}
+		if seen[sub.ID] {
+			return
+		}
+		seen[sub.ID] = true
+
+		text := strings.Join(strings.Fields(card.Text()), " ")
+		sub.Frequency = frequencyPattern.FindString(text)
+		if m := qtyPattern.FindStringSubmatch(text); m != nil {
+			sub.Quantity, _ = strconv.ParseFloat(m[1], 64)
+		}
+		if m := discountPattern.FindStringSubmatch(text); m != nil {
+			sub.DiscountPercent, _ = strconv.ParseFloat(m[1], 64)
+		}
+		sub.Price = p.subscriptionPrice(card, text)
+		sub.NextDelivery = p.nextDate(text)
+
+		subscriptions = append(subscriptions, sub)
+	})
+
+	if len(subscriptions) == 0 && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeSubscriptions, Err: ErrEncryptedContent}
+	}
+
+	return subscriptions, nil
+}
+
+// ParseMemberships parses the memberships and subscriptions page (Prime,
+// Kindle Unlimited, Audible, ...) and returns one subscription per plan
+func (p *Parser) ParseMemberships(r io.Reader) ([]*Subscription, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	if err := detectBlockedPage(doc.Selection); err != nil {
+		return nil, &PageError{PageType: PageTypeSubscriptions, Err: err}
+	}
+
+	var subscriptions []*Subscription
+	doc.Find(membershipCards).Each(func(i int, card *goquery.Selection) {
+		if card.ParentsFiltered(membershipCards).Length() > 0 {
+			return
+		}
+
+		name := card.Find("h2, h3, .mys-subscription-name, .a-text-bold").First()
+		sub := &Subscription{
+			ID:   card.AttrOr("data-subscription-id", ""),
+			Kind: SubscriptionMembership,
+			Name: strings.Join(strings.Fields(name.Text()), " "),
+		}
+		if sub.Name == "" {
+			return
+		}
+		if sub.ID == "" {
+			sub.ID = sub.Name
+		}
+
+		text := strings.Join(strings.Fields(card.Text()), " ")
+		sub.Frequency = billingPattern.FindString(text)
+		sub.Price = p.subscriptionPrice(card, text)
+		sub.NextDelivery = p.nextDate(text)
+
+		subscriptions = append(subscriptions, sub)
+	})
+
+	if len(subscriptions) == 0 && isEncrypted(doc.Selection) {
+		return nil, &PageError{PageType: PageTypeSubscriptions, Err: ErrEncryptedContent}
+	}
+
+	return subscriptions, nil
+}
+
+// subscriptionPrice reads a card's price, preferring the marked-up price
+// element over the first amount in its text
+func (p *Parser) subscriptionPrice(card *goquery.Selection, text string) Money {
+	if priceText := card.Find(".a-price .a-offscreen").First().Text(); priceText != "" {
+		return p.marketplace.ParsePrice(priceText)
+	}
+	price, _ := p.marketplace.findPrice(text)
+	return price
+}
+
+// nextDate reads the date following a label like "Next delivery" or
+// "Renews", resolving relative days and missing years forwards from today
+func (p *Parser) nextDate(text string) time.Time {
+	loc := nextDatePattern.FindStringIndex(text)
+	if loc == nil {
+		return time.Time{}
+	}
+	return p.statusDate(text[loc[0]:], time.Time{}, false)
+}
+
+// LinkSubscriptions sets SubscriptionID on the order items that a Subscribe &
+// Save subscription delivered: items with the subscription's ASIN in orders
+// whose charge summary has a Subscribe & Save discount. One-off purchases of
+// the same product, and orders without parsed details, are not linked.
+// It returns the number of items linked.
+func LinkSubscriptions(orders []*Order, subscriptions []*Subscription) int {
+	byASIN := make(map[string]*Subscription)
+	for _, sub := range subscriptions {
+		if sub.Kind == SubscriptionSubscribeSave && sub.ASIN != "" {
+			byASIN[sub.ASIN] = sub
+		}
+	}
+
+	linked := 0
+	for _, order := range orders {
+		if !hasSubscribeSaveDiscount(order) {
+			continue
+		}
+		for _, item := range order.Items {
+			if sub := byASIN[item.ASIN]; sub != nil {
+				item.SubscriptionID = sub.ID
+				linked++
+			}
+		}
+	}
+	return linked
+}
+
+// hasSubscribeSaveDiscount reports whether an order's charge summary has a Subscribe & Save row
+func hasSubscribeSaveDiscount(order *Order) bool {
+	for _, adj := range order.Adjustments {
+		if adj.Type == AdjustmentSubscribeSave {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchSubscribeSave fetches the Subscribe & Save management page
+func (c *Client) FetchSubscribeSave(ctx context.Context) ([]*Subscription, error) {
+	pageURL := c.pageURL(subscribeSavePath)
+
+	body, err := c.pageSource.FetchPage(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Subscribe & Save: %w", err)
+	}
+	defer body.Close()
+
+	subscriptions, err := c.newParser().ParseSubscribeSave(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Subscribe & Save: %w", withPageURL(err, pageURL))
+	}
+	return subscriptions, nil
+}
+
+// FetchMemberships fetches the memberships and subscriptions page
+func (c *Client) FetchMemberships(ctx context.Context) ([]*Subscription, error) {
+	pageURL := c.pageURL(membershipsPath)
+
+	body, err := c.pageSource.FetchPage(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch memberships: %w", err)
+	}
+	defer body.Close()
+
+	subscriptions, err := c.newParser().ParseMemberships(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse memberships: %w", withPageURL(err, pageURL))
+	}
+	return subscriptions, nil
+}
+
+// FetchSubscriptions fetches both Subscribe & Save items and memberships
+func (c *Client) FetchSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	subscriptions, err := c.FetchSubscribeSave(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := c.FetchMemberships(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug("parsed subscriptions",
+		"subscribe_and_save", len(subscriptions),
+		"memberships", len(memberships),
+	)
+
+	return append(subscriptions, memberships...), nil
+}
@@ -0,0 +1,172 @@
+package amazon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSubscribeSavePage = `<html><body>
+<div class="subscription-card" data-subscription-id="SUB-1">
+	<a href="/dp/B0DISHSOAP"><img src="soap.jpg"></a>
+	<a href="/dp/B0DISHSOAP">Dish Soap, 3 Pack</a>
+	<span>Qty: 2</span>
+	<span>Every 2 months</span>
+	<span class="a-price"><span class="a-offscreen">$8.49</span></span>
+	<span>You save 15%</span>
+	<span>Next delivery: Feb 3</span>
+	<button data-subscription-id="SUB-1">Skip</button>
+</div>
+<div class="subscription-card" data-subscription-id="SUB-2">
+	<a href="/dp/B0COFFEE01">Ground Coffee, 32 oz</a>
+	<span>Every 1 month</span>
+	<span>$12.99</span>
+	<span>Save 5%</span>
+	<span>Arriving tomorrow</span>
+</div>
+</body></html>`
+
+const testMembershipsPage = `<html><body>
+<div data-component="membershipCard">
+	<h3>Prime Membership</h3>
+	<span>$14.99 / month</span>
+	<span>Renews on March 1</span>
+</div>
+<div data-component="membershipCard">
+	<h3>Kindle Unlimited</h3>
+	<span>$11.99 monthly</span>
+	<span>Next payment January 28</span>
+</div>
+</body></html>`
+
+func TestParseSubscribeSave(t *testing.T) {
+	parser := NewParser()
+	parser.now = func() time.Time { return time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC) }
+
+	subs, err := parser.ParseSubscribeSave(strings.NewReader(testSubscribeSavePage))
+	if err != nil {
+		t.Fatalf("ParseSubscribeSave failed: %v", err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("Expected 2 subscriptions, got %d", len(subs))
+	}
+
+	soap := subs[0]
+	if soap.ID != "SUB-1" || soap.ASIN != "B0DISHSOAP" || soap.Name != "Dish Soap, 3 Pack" || soap.Kind != SubscriptionSubscribeSave {
+		t.Errorf("Unexpected subscription: %+v", soap)
+	}
+	if soap.Quantity != 2 || soap.Frequency != "Every 2 months" || soap.DiscountPercent != 15 {
+		t.Errorf("Unexpected qty/frequency/discount: %.0f %q %.0f", soap.Quantity, soap.Frequency, soap.DiscountPercent)
+	}
+	if soap.Price != NewMoney(849, "USD") {
+		t.Errorf("Expected price 8.49 USD, got %s", soap.Price)
+	}
+	if got := soap.NextDelivery.Format("2006-01-02"); got != "2025-02-03" {
+		t.Errorf("Expected next delivery 2025-02-03, got %s", got)
+	}
+
+	coffee := subs[1]
+	if coffee.Price != NewMoney(1299, "USD") || coffee.DiscountPercent != 5 || coffee.Quantity != 1 {
+		t.Errorf("Unexpected coffee subscription: %+v", coffee)
+	}
+	if got := coffee.NextDelivery.Format("2006-01-02"); got != "2025-01-21" {
+		t.Errorf("Expected next delivery 2025-01-21, got %s", got)
+	}
+}
+
+func TestParseMemberships(t *testing.T) {
+	parser := NewParser()
+	parser.now = func() time.Time { return time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC) }
+
+	subs, err := parser.ParseMemberships(strings.NewReader(testMembershipsPage))
+	if err != nil {
+		t.Fatalf("ParseMemberships failed: %v", err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("Expected 2 memberships, got %d", len(subs))
+	}
+
+	prime := subs[0]
+	if prime.Name != "Prime Membership" || prime.Kind != SubscriptionMembership || prime.Price != NewMoney(1499, "USD") {
+		t.Errorf("Unexpected membership: %+v", prime)
+	}
+	if prime.Frequency != "/ month" {
+		t.Errorf("Expected frequency %q, got %q", "/ month", prime.Frequency)
+	}
+	if got := prime.NextDelivery.Format("2006-01-02"); got != "2025-03-01" {
+		t.Errorf("Expected renewal 2025-03-01, got %s", got)
+	}
+	if subs[1].Frequency != "monthly" || subs[1].NextDelivery.Format("2006-01-02") != "2025-01-28" {
+		t.Errorf("Unexpected second membership: %q %v", subs[1].Frequency, subs[1].NextDelivery)
+	}
+}
+
+func TestLinkSubscriptions(t *testing.T) {
+	subs := []*Subscription{
+		{ID: "SUB-1", Kind: SubscriptionSubscribeSave, ASIN: "B0DISHSOAP"},
+		{ID: "Prime Membership", Kind: SubscriptionMembership},
+	}
+	snsOrder := &Order{
+		ID:          "114-0000000-0000001",
+		Adjustments: []*Adjustment{{Type: AdjustmentSubscribeSave, Amount: NewMoney(-150, "USD")}},
+		Items:       []*OrderItem{{ASIN: "B0DISHSOAP"}, {ASIN: "B0OTHER001"}},
+	}
+	oneOff := &Order{
+		ID:          "114-0000000-0000002",
+		Adjustments: []*Adjustment{{Type: AdjustmentSubtotal, Amount: NewMoney(999, "USD")}},
+		Items:       []*OrderItem{{ASIN: "B0DISHSOAP"}},
+	}
+	// A one-off purchase without a charge summary can't be told apart
+	summaryOnly := &Order{ID: "114-0000000-0000003", Items: []*OrderItem{{ASIN: "B0DISHSOAP"}}}
+
+	linked := LinkSubscriptions([]*Order{snsOrder, oneOff, summaryOnly}, subs)
+	if linked != 1 {
+		t.Errorf("Expected 1 linked item, got %d", linked)
+	}
+	if snsOrder.Items[0].SubscriptionID != "SUB-1" || snsOrder.Items[1].SubscriptionID != "" {
+		t.Errorf("Unexpected links in Subscribe & Save order: %q %q", snsOrder.Items[0].SubscriptionID, snsOrder.Items[1].SubscriptionID)
+	}
+	if oneOff.Items[0].SubscriptionID != "" {
+		t.Error("Expected one-off purchase without a Subscribe & Save discount to stay unlinked")
+	}
+	if summaryOnly.Items[0].SubscriptionID != "" {
+		t.Error("Expected order without a charge summary to stay unlinked")
+	}
+}
+
+func TestClient_FetchSubscriptions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(subscribeSavePath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testSubscribeSavePage))
+	})
+	mux.HandleFunc(membershipsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testMembershipsPage))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(
+		WithCookieFile(filepath.Join(t.TempDir(), "cookies.json")),
+		WithBaseURL(server.URL),
+		WithRateLimit(0),
+		WithAutoSave(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	subs, err := client.FetchSubscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("FetchSubscriptions failed: %v", err)
+	}
+	if len(subs) != 4 {
+		t.Fatalf("Expected 4 subscriptions, got %d", len(subs))
+	}
+	if subs[0].Kind != SubscriptionSubscribeSave || subs[3].Kind != SubscriptionMembership {
+		t.Errorf("Expected Subscribe & Save items before memberships, got %s and %s", subs[0].Kind, subs[3].Kind)
+	}
+}
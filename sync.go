@@ -0,0 +1,163 @@
+package amazon
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// syncLookback is how far before the last sync an incremental sync starts,
+// so totals that change after an order is placed (e.g. split shipments) are picked up
+const syncLookback = 30 * 24 * time.Hour
+
+// SyncOptions specifies options for syncing orders into an OrderStore
+type SyncOptions struct {
+	// StartDate, EndDate and Year select which order list pages are read.
+	// If none are set, the sync starts shortly before the store's last sync,
+	// or covers every year in the order list's year dropdown on the first sync.
+	StartDate time.Time
+	EndDate   time.Time
+	Year      int
+
+	// IncludeTransactions also fetches payment transactions for new and changed orders
+	IncludeTransactions bool
+
+	// IncludeDigital also syncs digital orders (Kindle, Prime Video, apps and music)
+	IncludeDigital bool
+}
+
+// SyncResult reports what a sync changed
+type SyncResult struct {
+	New       int // Orders not previously in the store
+	Updated   int // Orders whose total changed on the order list page
+	Unchanged int // Orders whose details were not re-fetched
+	Failed    int // Orders whose details could not be fetched
+	Skipped   int // Order lists that could not be read
+}
+
+// Sync reads the order list pages and fetches details only for orders the
+// store has not seen or whose totals changed, then saves the store
+func (c *Client) Sync(ctx context.Context, store *OrderStore, opts SyncOptions) (*SyncResult, error) {
+	fetchOpts := FetchOptions{
+		StartDate:      opts.StartDate,
+		EndDate:        opts.EndDate,
+		Year:           opts.Year,
+		IncludeDigital: opts.IncludeDigital,
+	}
+
+	// Incremental sync: only look at pages since the last sync
+	lastSync := store.LastSync()
+	if fetchOpts.Year == 0 && fetchOpts.StartDate.IsZero() && fetchOpts.EndDate.IsZero() && !lastSync.IsZero() {
+		fetchOpts.StartDate = lastSync.Add(-syncLookback)
+		fetchOpts.EndDate = time.Now()
+	}
+
+	syncStarted := time.Now()
+
+	list, err := c.FetchOrderSummaries(ctx, fetchOpts)
+	if err != nil {
+		return nil, err
+	}
+	summaries := list.Summaries
+
+	c.logger.Info("syncing orders", "count", len(summaries), "since", fetchOpts.StartDate)
+
+	result := &SyncResult{Skipped: list.Skipped}
+	parser := c.newParser()
+
+	for i, summary := range summaries {
+		if err := ctx.Err(); err != nil {
+			return result, c.saveSync(store, time.Time{}, err)
+		}
+
+		existing := store.Order(summary.ID)
+		if existing != nil {
+			if listTotal, ok := store.listTotal(summary.ID); ok && sameAmount(listTotal, summary.Total) {
+				result.Unchanged++
+				continue
+			}
+		}
+
+		c.logger.Debug("fetching order details", "orderID", summary.ID)
+
+		order, err := c.fetchOrderDetails(ctx, summary.ID, parser)
+		c.hooks.detail(DetailEvent{
+			PageType: PageTypeOrderDetails,
+			OrderID:  summary.ID,
+			Err:      err,
+			Done:     i + 1,
+			Total:    len(summaries),
+		})
+		if err != nil {
+			if isBlockedPageError(err) {
+				return result, c.saveSync(store, time.Time{}, err)
+			}
+			c.logger.Warn("failed to fetch order details",
+				"orderID", summary.ID,
+				"error", err,
+			)
+			// Leave the order out; the last sync time isn't advanced below,
+			// so the next sync reads this order's list again and retries it
+			result.Failed++
+			continue
+		}
+		store.PutOrder(mergeOrderSummary(order, summary))
+		store.setListTotal(summary.ID, summary.Total)
+
+		if existing == nil {
+			result.New++
+		} else {
+			result.Updated++
+		}
+
+		if opts.IncludeTransactions {
+			transactions, err := c.FetchTransactions(ctx, summary.ID)
+			if err != nil {
+				c.logger.Warn("failed to fetch transactions",
+					"orderID", summary.ID,
+					"error", err,
+				)
+				continue
+			}
+			store.PutTransactions(summary.ID, withRefundTransactions(transactions, order.Refunds))
+		}
+	}
+
+	// Keep the previous sync time when anything was missed, otherwise an
+	// incremental sync would start past orders older than syncLookback
+	if result.Failed > 0 || result.Skipped > 0 {
+		c.logger.Warn("sync incomplete, keeping last sync time",
+			"failed", result.Failed,
+			"skipped", result.Skipped,
+		)
+		return result, c.saveSync(store, time.Time{}, nil)
+	}
+
+	return result, c.saveSync(store, syncStarted, nil)
+}
+
+// saveSync saves the store, recording syncedAt as the last sync time if set.
+// syncErr is returned unchanged so partial progress is kept on failure.
+func (c *Client) saveSync(store *OrderStore, syncedAt time.Time, syncErr error) error {
+	if !syncedAt.IsZero() {
+		store.SetLastSync(syncedAt)
+	}
+
+	if err := store.Save(); err != nil {
+		if syncErr != nil {
+			return syncErr
+		}
+		return fmt.Errorf("failed to save order store: %w", err)
+	}
+
+	return syncErr
+}
+
+// sameAmount compares two amounts exactly. Totals stored before amounts had
+// a currency match any currency.
+func sameAmount(a, b Money) bool {
+	if a.Currency != "" && b.Currency != "" && a.Currency != b.Currency {
+		return false
+	}
+	return a.Units == b.Units
+}
@@ -15,13 +15,20 @@ type OrderItemInterface interface {
 
 // Order represents an Amazon order with all its details
 type Order struct {
-	ID           string
-	Date         time.Time
-	Total        float64
-	Subtotal     float64
-	Tax          float64
-	ShippingFees float64
-	Items        []*OrderItem
+	ID           string        `json:"id"`
+	Kind         OrderKind     `json:"kind,omitempty"`
+	Date         time.Time     `json:"date"`
+	Total        Money         `json:"total"`
+	Subtotal     Money         `json:"subtotal"`
+	Tax          Money         `json:"tax"`
+	ShippingFees Money         `json:"shipping_fees"`
+	Tip          Money         `json:"tip"`                   // Delivery driver tip (Fresh and Whole Foods orders)
+	BagFees      Money         `json:"bag_fees"`              // Bag fees (Fresh and Whole Foods orders)
+	Adjustments  []*Adjustment `json:"adjustments,omitempty"` // Full charge summary, in page order
+	Items        []*OrderItem  `json:"items"`
+	Shipments    []*Shipment   `json:"shipments,omitempty"` // Items grouped by package; shares items with Items
+	Refunds      []*Refund     `json:"refunds,omitempty"`   // Returns and refunds shown on the order
+	Invoice      *Invoice      `json:"invoice,omitempty"`   // Printable invoice; set by FetchInvoice or ApplyInvoice
 }
 
 // GetID returns the order ID
@@ -36,27 +43,27 @@ func (o *Order) GetDate() time.Time {
 
 // GetTotal returns the grand total of the order
 func (o *Order) GetTotal() float64 {
-	return o.Total
+	return o.Total.Float64()
 }
 
 // GetSubtotal returns the subtotal before tax and fees
 func (o *Order) GetSubtotal() float64 {
-	return o.Subtotal
+	return o.Subtotal.Float64()
 }
 
 // GetTax returns the tax amount
 func (o *Order) GetTax() float64 {
-	return o.Tax
+	return o.Tax.Float64()
 }
 
-// GetTip returns the tip amount (Amazon doesn't support tips, always 0)
+// GetTip returns the delivery tip, which only grocery orders have
 func (o *Order) GetTip() float64 {
-	return 0
+	return o.Tip.Float64()
 }
 
-// GetFees returns shipping and handling fees
+// GetFees returns shipping, delivery and bag fees
 func (o *Order) GetFees() float64 {
-	return o.ShippingFees
+	return o.ShippingFees.Add(o.BagFees).Float64()
 }
 
 // GetItems returns all items in the order
@@ -76,13 +83,18 @@ func (o *Order) GetRawData() interface{} {
 
 // OrderItem represents a single item in an Amazon order
 type OrderItem struct {
-	Name        string
-	Price       float64
-	Quantity    float64
-	UnitPrice   float64
-	ASIN        string
-	Description string
-	Category    string
+	Name           string  `json:"name"`
+	Price          Money   `json:"price"` // Line total: UnitPrice x Quantity, or as charged for weighted items
+	Quantity       float64 `json:"quantity"`
+	Unit           string  `json:"unit,omitempty"` // Weight unit for items sold by weight, e.g. "lb"; empty for counts
+	UnitPrice      Money   `json:"unit_price"`     // Price per item, or per Unit for weighted items
+	ASIN           string  `json:"asin"`
+	Description    string  `json:"description,omitempty"`
+	Category       string  `json:"category,omitempty"`
+	Seller         string  `json:"seller,omitempty"`          // "Sold by" seller, from the invoice
+	Condition      string  `json:"condition,omitempty"`       // "New", "Used - Very Good", ..., from the invoice
+	SubstituteFor  string  `json:"substitute_for,omitempty"`  // Name of the item ordered, if this one was substituted for it
+	SubscriptionID string  `json:"subscription_id,omitempty"` // Subscribe & Save subscription that delivered the item; set by LinkSubscriptions
 }
 
 // GetName returns the item name
@@ -92,7 +104,7 @@ func (i *OrderItem) GetName() string {
 
 // GetPrice returns the line total for this item
 func (i *OrderItem) GetPrice() float64 {
-	return i.Price
+	return i.Price.Float64()
 }
 
 // GetQuantity returns the quantity of this item
@@ -102,7 +114,7 @@ func (i *OrderItem) GetQuantity() float64 {
 
 // GetUnitPrice returns the unit price of this item
 func (i *OrderItem) GetUnitPrice() float64 {
-	return i.UnitPrice
+	return i.UnitPrice.Float64()
 }
 
 // GetDescription returns the item description
@@ -120,27 +132,68 @@ func (i *OrderItem) GetCategory() string {
 	return i.Category
 }
 
+// ShipmentState is the normalized delivery state of a shipment
+type ShipmentState string
+
+// Shipment states
+const (
+	ShipmentUnknown    ShipmentState = ""
+	ShipmentNotShipped ShipmentState = "not_shipped" // "Not yet shipped", "Preparing for shipment"
+	ShipmentInTransit  ShipmentState = "in_transit"  // "Shipped", "Arriving tomorrow", "Out for delivery"
+	ShipmentDelivered  ShipmentState = "delivered"   // "Delivered Jan 5"
+	ShipmentCancelled  ShipmentState = "cancelled"
+	ShipmentReturned   ShipmentState = "returned" // "Return complete", "Refunded"
+)
+
+// Shipment is a group of an order's items that ship together in one package
+type Shipment struct {
+	Status        string        `json:"status"`         // Status as shown, e.g. "Delivered January 5" or "Arriving tomorrow"
+	State         ShipmentState `json:"state"`          // Normalized from Status
+	PromisedDate  time.Time     `json:"promised_date"`  // Expected delivery date, if not yet delivered
+	DeliveredDate time.Time     `json:"delivered_date"` // Actual delivery date
+	TrackingURL   string        `json:"tracking_url,omitempty"`
+	Tracking      *Tracking     `json:"tracking,omitempty"` // Set by FetchTracking
+	Items         []*OrderItem  `json:"items"`
+}
+
+// Tracking holds carrier details from a shipment's tracking page
+type Tracking struct {
+	Carrier        string `json:"carrier"`         // e.g. "UPS" or "Amazon"
+	TrackingNumber string `json:"tracking_number"` // Carrier tracking ID
+	Status         string `json:"status,omitempty"`
+}
+
+// Refund is money returned for some or all of an order's items
+type Refund struct {
+	OrderID string       `json:"order_id"`
+	Items   []*OrderItem `json:"items,omitempty"` // Returned items, if known
+	Amount  Money        `json:"amount"`          // Amount refunded (positive value); zero if not yet issued
+	Date    time.Time    `json:"date"`            // Date the refund was issued, if shown
+	Status  string       `json:"status"`          // Status as shown, e.g. "Return complete" or "Refund issued"
+}
+
 // OrderSummary represents basic order info from the order list page
 type OrderSummary struct {
-	ID         string
-	Date       time.Time
-	Total      float64
-	ItemCount  int
-	ItemNames  []string
-	DetailURL  string
+	ID        string    `json:"id"`
+	Kind      OrderKind `json:"kind,omitempty"`
+	Date      time.Time `json:"date"`
+	Total     Money     `json:"total"`
+	ItemCount int       `json:"item_count"`
+	ItemNames []string  `json:"item_names"`
+	DetailURL string    `json:"detail_url"`
 }
 
 // Transaction represents a payment transaction for an order
 // This is the actual charge made to a payment method
 type Transaction struct {
-	OrderID       string    // The associated order ID (e.g., "114-9733092-9360267")
-	Date          time.Time // Date the charge was made
-	Amount        float64   // Amount charged (positive value)
-	PaymentMethod string    // Payment method description (e.g., "Prime Visa ****1211")
-	CardType      string    // Card type extracted (e.g., "Visa", "Mastercard", "Amex")
-	LastFour      string    // Last 4 digits of card (e.g., "1211")
-	Merchant      string    // Merchant name (e.g., "AMZN Mktp US")
-	Status        string    // Transaction status (e.g., "Completed", "Pending", "Refunded")
+	OrderID       string    `json:"order_id"`       // The associated order ID (e.g., "114-9733092-9360267")
+	Date          time.Time `json:"date"`           // Date the charge was made
+	Amount        Money     `json:"amount"`         // Amount charged (positive value); refunds are negative
+	PaymentMethod string    `json:"payment_method"` // Payment method description (e.g., "Prime Visa ****1211")
+	CardType      string    `json:"card_type"`      // Card type extracted (e.g., "Visa", "Mastercard", "Amex")
+	LastFour      string    `json:"last_four"`      // Last 4 digits of card (e.g., "1211")
+	Merchant      string    `json:"merchant"`       // Merchant name (e.g., "AMZN Mktp US")
+	Status        string    `json:"status"`         // Transaction status (e.g., "Completed", "Pending", "Refunded")
 }
 
 // GetOrderID returns the associated order ID
@@ -155,7 +208,7 @@ func (t *Transaction) GetDate() time.Time {
 
 // GetAmount returns the transaction amount
 func (t *Transaction) GetAmount() float64 {
-	return t.Amount
+	return t.Amount.Float64()
 }
 
 // GetPaymentMethod returns the payment method description